@@ -0,0 +1,12 @@
+# Route changes of the last 30 days
+data "pomeriumzero_activity_log" "route_changes" {
+  since  = timeadd(plantimestamp(), "-720h")
+  action = "route.updated"
+}
+
+output "route_changes" {
+  value = [
+    for entry in data.pomeriumzero_activity_log.route_changes.entries :
+    "${entry.timestamp} ${entry.actor} ${entry.action} ${entry.resource_id}"
+  ]
+}
@@ -0,0 +1,11 @@
+data "pomeriumzero_certificates" "expiring" {
+  cluster_id     = data.pomeriumzero_cluster.default.id
+  expires_within = "720h"
+}
+
+output "expiring_certificates" {
+  value = {
+    for certificate in data.pomeriumzero_certificates.expiring.certificates :
+    join(", ", certificate.domains) => certificate.not_after
+  }
+}
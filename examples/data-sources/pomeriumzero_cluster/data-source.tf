@@ -1,3 +1,13 @@
 data "pomeriumzero_cluster" "default" {
   name = "gifted-nightingale-1337"
 }
+
+# Wait for a cluster created earlier in a bootstrap pipeline to show up
+data "pomeriumzero_cluster" "bootstrapped" {
+  name = "bootstrapped-cluster"
+
+  wait_for {
+    timeout       = "10m"
+    poll_interval = "15s"
+  }
+}
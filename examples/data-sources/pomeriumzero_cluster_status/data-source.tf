@@ -0,0 +1,10 @@
+data "pomeriumzero_cluster_status" "default" {
+  cluster_id = data.pomeriumzero_cluster.default.id
+}
+
+check "cluster_healthy" {
+  assert {
+    condition     = data.pomeriumzero_cluster_status.default.healthy && data.pomeriumzero_cluster_status.default.connected_instances > 0
+    error_message = "The cluster is ${data.pomeriumzero_cluster_status.default.status} with ${data.pomeriumzero_cluster_status.default.connected_instances} connected instances."
+  }
+}
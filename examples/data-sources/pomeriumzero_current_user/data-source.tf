@@ -0,0 +1,9 @@
+data "pomeriumzero_current_user" "me" {}
+
+# Fail the plan early when the API token cannot manage the organization
+check "admin_token" {
+  assert {
+    condition     = contains(data.pomeriumzero_current_user.me.roles, "admin")
+    error_message = "The API token of ${data.pomeriumzero_current_user.me.subject} does not have the admin role."
+  }
+}
@@ -0,0 +1,9 @@
+data "pomeriumzero_devices" "pending" {
+  approval_state = "pending"
+}
+
+output "pending_devices" {
+  value = {
+    for device in data.pomeriumzero_devices.pending.devices : device.id => device.owner_email
+  }
+}
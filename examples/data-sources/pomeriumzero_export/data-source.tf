@@ -0,0 +1,8 @@
+data "pomeriumzero_export" "backup" {
+  redact_secrets = true
+}
+
+resource "local_sensitive_file" "backup" {
+  filename = "${path.module}/pomerium-zero-backup.json"
+  content  = data.pomeriumzero_export.backup.json
+}
@@ -0,0 +1,12 @@
+data "pomeriumzero_identity_providers" "supported" {}
+
+locals {
+  identity_provider = "okta"
+}
+
+check "identity_provider_supported" {
+  assert {
+    condition     = contains([for idp in data.pomeriumzero_identity_providers.supported.identity_providers : idp.type], local.identity_provider)
+    error_message = "Identity provider ${local.identity_provider} is not supported by Pomerium Zero."
+  }
+}
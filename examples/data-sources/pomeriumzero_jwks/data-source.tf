@@ -0,0 +1,14 @@
+data "pomeriumzero_jwks" "default" {
+  cluster_id = data.pomeriumzero_cluster.default.id
+}
+
+# Let the upstream application verify the X-Pomerium-Jwt-Assertion header
+resource "kubernetes_config_map" "grafana_jwt" {
+  metadata {
+    name = "grafana-jwt"
+  }
+
+  data = {
+    GF_AUTH_JWT_JWK_SET_URL = data.pomeriumzero_jwks.default.jwks_url
+  }
+}
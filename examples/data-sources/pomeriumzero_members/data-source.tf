@@ -0,0 +1,9 @@
+data "pomeriumzero_members" "admins" {
+  role = "admin"
+}
+
+output "admin_last_logins" {
+  value = {
+    for member in data.pomeriumzero_members.admins.members : member.email => coalesce(member.last_login_at, "never")
+  }
+}
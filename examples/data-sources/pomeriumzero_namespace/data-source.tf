@@ -0,0 +1,10 @@
+data "pomeriumzero_namespace" "team_a" {
+  path = "Production/team-a"
+}
+
+resource "pomeriumzero_route" "grafana" {
+  name         = "Grafana"
+  namespace_id = data.pomeriumzero_namespace.team_a.id
+  from         = "https://grafana.example.com"
+  to           = ["http://grafana.internal:3000"]
+}
@@ -0,0 +1,15 @@
+data "pomeriumzero_namespaces" "all" {}
+
+resource "pomeriumzero_policy" "team_a" {
+  name         = "Team A"
+  namespace_id = data.pomeriumzero_namespaces.all.ids_by_path["Production/team-a"]
+  description  = "Allow members of team A"
+  enforced     = false
+  explanation  = "Only members of team A can access this route"
+  remediation  = "Ask to be added to team A"
+  ppl = jsonencode({
+    allow = {
+      and = [{ groups = { has = "team-a" } }]
+    }
+  })
+}
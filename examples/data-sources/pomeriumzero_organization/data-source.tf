@@ -0,0 +1,13 @@
+data "pomeriumzero_organization" "current" {}
+
+output "organization" {
+  value = "${data.pomeriumzero_organization.current.name} (${data.pomeriumzero_organization.current.plan})"
+}
+
+# Only create the staging cluster when the plan allows more than one cluster
+resource "pomeriumzero_cluster" "staging" {
+  count = lookup(data.pomeriumzero_organization.current.limits, "clusters", 1) > 1 ? 1 : 0
+
+  name   = "staging"
+  domain = "staging"
+}
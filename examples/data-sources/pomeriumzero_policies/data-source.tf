@@ -0,0 +1,8 @@
+data "pomeriumzero_policies" "team" {
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  name_prefix  = "team-"
+}
+
+output "unattached_policies" {
+  value = [for policy in data.pomeriumzero_policies.team.policies : policy.name if policy.route_count == 0 && !policy.enforced]
+}
@@ -2,3 +2,7 @@ data "pomeriumzero_policy" "allow_any_authenticated_user" {
   namespace_id = data.pomeriumzero_cluster.default.namespace_id
   name         = "Allow Any Authenticated User"
 }
+
+output "allow_any_authenticated_user_routes" {
+  value = [for route in data.pomeriumzero_policy.allow_any_authenticated_user.routes : route.name]
+}
@@ -0,0 +1,13 @@
+data "pomeriumzero_policy_templates" "domain" {
+  name = "Allow Domain"
+}
+
+resource "pomeriumzero_policy" "from_template" {
+  name         = "Allow Example Domain"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  description  = data.pomeriumzero_policy_templates.domain.templates[0].description
+  enforced     = false
+  explanation  = "Only users of the example.com domain can access this route"
+  remediation  = "Sign in with your example.com account"
+  ppl          = replace(data.pomeriumzero_policy_templates.domain.templates[0].ppl, "example.org", "example.com")
+}
@@ -0,0 +1,7 @@
+data "pomeriumzero_releases" "stable" {
+  channel = "stable"
+}
+
+output "latest_stable_version" {
+  value = data.pomeriumzero_releases.stable.latest_version
+}
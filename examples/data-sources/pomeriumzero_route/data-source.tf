@@ -0,0 +1,8 @@
+data "pomeriumzero_route" "grafana" {
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  name         = "Grafana"
+}
+
+data "pomeriumzero_route" "wiki" {
+  from = "https://wiki.example.com"
+}
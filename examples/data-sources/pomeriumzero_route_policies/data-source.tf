@@ -0,0 +1,15 @@
+data "pomeriumzero_route_policies" "grafana" {
+  name = "Grafana"
+}
+
+data "pomeriumzero_policy" "baseline" {
+  name         = "Baseline"
+  namespace_id = data.pomeriumzero_route_policies.grafana.namespace_id
+}
+
+check "grafana_baseline_policy" {
+  assert {
+    condition     = contains(data.pomeriumzero_route_policies.grafana.policy_ids, data.pomeriumzero_policy.baseline.id)
+    error_message = "The Grafana route must carry the baseline policy."
+  }
+}
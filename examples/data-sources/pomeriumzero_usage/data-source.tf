@@ -0,0 +1,12 @@
+data "pomeriumzero_usage" "current" {}
+
+locals {
+  new_routes = 5
+}
+
+check "route_quota" {
+  assert {
+    condition     = data.pomeriumzero_usage.current.routes.remaining == null || data.pomeriumzero_usage.current.routes.remaining >= local.new_routes
+    error_message = "Adding ${local.new_routes} routes exceeds the route quota: ${data.pomeriumzero_usage.current.routes.used} of ${data.pomeriumzero_usage.current.routes.limit} are used."
+  }
+}
@@ -0,0 +1,13 @@
+ephemeral "pomeriumzero_cluster_bootstrap_token" "default" {
+  cluster_id = pomeriumzero_cluster.default.id
+  ttl        = "1h"
+}
+
+# Ephemeral values can only be passed into ephemeral contexts, such as write-only attributes.
+# Here the token is stored in SSM, from where cloud-init fetches it when the host boots.
+resource "aws_ssm_parameter" "pomerium_bootstrap_token" {
+  name             = "/pomerium/bootstrap-token"
+  type             = "SecureString"
+  value_wo         = ephemeral.pomeriumzero_cluster_bootstrap_token.default.token
+  value_wo_version = 1
+}
@@ -0,0 +1,17 @@
+ephemeral "pomeriumzero_console_token" "ci" {
+  role         = "editor"
+  namespace_id = pomeriumzero_cluster.default.namespace_id
+  ttl          = "15m"
+}
+
+# Ephemeral values can be used in provisioners, e.g. to run a script against the API
+resource "terraform_data" "sync_routes" {
+  triggers_replace = [pomeriumzero_cluster.default.id]
+
+  provisioner "local-exec" {
+    command = "./scripts/sync-routes.sh"
+    environment = {
+      POMERIUM_ZERO_TOKEN = ephemeral.pomeriumzero_console_token.ci.token
+    }
+  }
+}
@@ -0,0 +1,10 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_policy" "employees" {
+  name         = "Employees"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  description  = "Allow users of the company domains"
+  enforced     = false
+  explanation  = "Only employees can access this route"
+  remediation  = "Sign in with your company account"
+  ppl          = provider::pomeriumzero::allow_domains(["example.com", "example.org"])
+}
@@ -0,0 +1,10 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_policy" "on_call" {
+  name         = "On-call engineers"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  description  = "Allow the on-call engineers"
+  enforced     = false
+  explanation  = "Only on-call engineers can access this route"
+  remediation  = "Ask to be added to the on-call rotation"
+  ppl          = provider::pomeriumzero::allow_emails(["alice@example.com", "bob@example.com"])
+}
@@ -0,0 +1,10 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_policy" "admins" {
+  name         = "Admins"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  description  = "Allow members of the admins group"
+  enforced     = false
+  explanation  = "Only admins can access this route"
+  remediation  = "Ask to be added to the admins group"
+  ppl          = provider::pomeriumzero::allow_groups(["admins"])
+}
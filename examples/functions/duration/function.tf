@@ -0,0 +1,7 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_cluster_settings" "default" {
+  cluster_domain           = pomeriumzero_cluster.default.fqdn
+  cookie_expire            = provider::pomeriumzero::duration("14h")
+  default_upstream_timeout = provider::pomeriumzero::duration("30s")
+  timeout_idle             = provider::pomeriumzero::duration("5m")
+}
@@ -0,0 +1,10 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_policy" "engineering" {
+  name         = "Engineering"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  ppl = provider::pomeriumzero::merge_ppl([
+    provider::pomeriumzero::allow_groups(["engineering"]),
+    provider::pomeriumzero::allow_emails(["contractor@example.org"]),
+    jsonencode({ deny = { or = [{ source_ip = "203.0.113.0/24" }] } }),
+  ])
+}
@@ -0,0 +1,18 @@
+# Provider-defined functions require Terraform 1.8 or later
+variable "apps" {
+  type = map(string)
+  default = {
+    grafana = "Grafana.Example.com"
+    wiki    = "https://wiki.example.com:443/"
+  }
+}
+
+resource "pomeriumzero_route" "apps" {
+  for_each = var.apps
+
+  name         = each.key
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  from         = provider::pomeriumzero::normalize_route_url(each.value)
+  to           = [provider::pomeriumzero::normalize_route_url("http://${each.key}.internal:80")]
+  policy_ids   = [pomeriumzero_policy.admins.id]
+}
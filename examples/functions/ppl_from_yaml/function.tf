@@ -0,0 +1,18 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_policy" "admins" {
+  name         = "Admins"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  description  = "Allow admins of the example.com domain"
+  enforced     = false
+  explanation  = "Only admins can access this route"
+  remediation  = "Ask to be added to the admins group"
+  ppl = provider::pomeriumzero::ppl_from_yaml(<<-EOT
+    allow:
+      and:
+        - domain:
+            is: example.com
+        - groups:
+            has: admins
+  EOT
+  )
+}
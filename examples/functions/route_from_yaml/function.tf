@@ -0,0 +1,20 @@
+# Provider-defined functions require Terraform 1.8 or later
+locals {
+  grafana = provider::pomeriumzero::route_from_yaml(<<-EOT
+    from: https://grafana.example.com
+    to: http://grafana.monitoring.svc.cluster.local:3000
+    allow_websockets: true
+    pass_identity_headers: true
+  EOT
+  )
+}
+
+resource "pomeriumzero_route" "grafana" {
+  name                  = local.grafana.name
+  namespace_id          = data.pomeriumzero_cluster.default.namespace_id
+  from                  = local.grafana.from
+  to                    = local.grafana.to
+  allow_websockets      = local.grafana.allow_websockets
+  pass_identity_headers = local.grafana.pass_identity_headers
+  policy_ids            = [pomeriumzero_policy.admins.id]
+}
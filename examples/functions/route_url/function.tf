@@ -0,0 +1,10 @@
+# Provider-defined functions require Terraform 1.8 or later
+resource "pomeriumzero_route" "apps" {
+  for_each = toset(["grafana", "wiki"])
+
+  name         = each.key
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  from         = provider::pomeriumzero::route_url(data.pomeriumzero_cluster.default.fqdn, each.key)
+  to           = ["http://${each.key}.internal"]
+  policy_ids   = [pomeriumzero_policy.admins.id]
+}
@@ -0,0 +1,15 @@
+# Provider-defined functions require Terraform 1.8 or later
+variable "ppl" {
+  type = string
+
+  validation {
+    condition     = can(provider::pomeriumzero::validate_ppl(var.ppl))
+    error_message = "The ppl variable must be a valid PPL document."
+  }
+}
+
+resource "pomeriumzero_policy" "custom" {
+  name         = "Custom"
+  namespace_id = data.pomeriumzero_cluster.default.namespace_id
+  ppl          = provider::pomeriumzero::validate_ppl(var.ppl)
+}
@@ -8,6 +8,15 @@ terraform {
 
 provider "pomeriumzero" {
   api_token = var.pomerium_zero_api_token
+
+  # Select the organization to manage when the API token has access to more than one
+  # organization_name = "Example Corp"
+
+  # Optional defaults for attributes left unset on pomeriumzero_route resources
+  route_defaults {
+    pass_identity_headers = true
+    allow_websockets      = true
+  }
 }
 
 # Get an API token at https://console.pomerium.app/app/management/api-tokens
@@ -0,0 +1,2 @@
+# Alert rules can be imported by specifying the alert rule ID.
+terraform import pomeriumzero_alert_rule.cluster_offline hGfEdCbAzYxWvUtSrQpOnMlKjIh
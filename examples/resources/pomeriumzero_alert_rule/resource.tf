@@ -0,0 +1,23 @@
+resource "pomeriumzero_alert_rule" "cluster_offline" {
+  name       = "Production cluster offline"
+  type       = "cluster_offline"
+  cluster_id = pomeriumzero_cluster.production.id
+  threshold  = "5m"
+
+  notification_webhook_ids = [pomeriumzero_notification_webhook.slack.id]
+}
+
+resource "pomeriumzero_alert_rule" "certificate_expiry" {
+  name      = "Certificate expires within 30 days"
+  type      = "certificate_expiry"
+  threshold = "720h"
+  emails    = ["platform-team@example.com"]
+}
+
+resource "pomeriumzero_alert_rule" "changeset_failure" {
+  name = "Changeset failed to apply"
+  type = "changeset_failure"
+
+  notification_webhook_ids = [pomeriumzero_notification_webhook.slack.id]
+  emails                   = ["platform-team@example.com"]
+}
@@ -0,0 +1,2 @@
+# API tokens can be imported by specifying the token ID. The token secret is only returned on creation and cannot be imported.
+terraform import pomeriumzero_api_token.ci tKjHgFdSaQwErTyUiOpLmNbVcXz
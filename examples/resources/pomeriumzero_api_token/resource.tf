@@ -0,0 +1,15 @@
+# Rotate the token used by CI every 90 days
+resource "time_rotating" "ci_token" {
+  rotation_days = 90
+}
+
+resource "pomeriumzero_api_token" "ci" {
+  name       = "ci-${time_rotating.ci_token.id}"
+  role       = "editor"
+  expires_at = timeadd(time_rotating.ci_token.id, "2160h")
+}
+
+output "ci_token" {
+  value     = pomeriumzero_api_token.ci.token
+  sensitive = true
+}
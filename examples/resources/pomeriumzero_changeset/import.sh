@@ -0,0 +1,2 @@
+# The changeset resource of a cluster can be imported by specifying the cluster id.
+terraform import pomeriumzero_changeset.default bZPhcRUBcFwVlLCEPsSHMTxEqLR
@@ -0,0 +1,9 @@
+resource "pomeriumzero_changeset" "default" {
+  cluster_id = pomeriumzero_cluster.default.id
+
+  # Apply again whenever the routes or policies managed in this workspace change
+  triggers = {
+    verify_route = pomeriumzero_route.verify.id
+    policy       = sha1(pomeriumzero_policy.allow_any_authenticated_user.ppl)
+  }
+}
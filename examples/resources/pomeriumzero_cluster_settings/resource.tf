@@ -7,11 +7,14 @@ resource "pomeriumzero_cluster_settings" "default" {
   default_upstream_timeout        = "30s"
   dns_lookup_family               = "V4_PREFERRED"
   authenticate_service_url        = "https://authenticate.${pomeriumzero_cluster.default.fqdn}"
+  cluster_domain                  = pomeriumzero_cluster.default.fqdn
   identity_provider               = var.pomerium_zero_identity_provider
   identity_provider_client_id     = var.pomerium_zero_identity_provider_client_id
   identity_provider_client_secret = var.pomerium_zero_identity_provider_client_secret
   identity_provider_url           = var.pomerium_zero_identity_provider_url
   log_level                       = "info"
+  log_format                      = "json"
+  access_log_enabled              = true
   pass_identity_headers           = false
   proxy_log_level                 = "info"
   skip_xff_append                 = false
@@ -19,6 +22,16 @@ resource "pomeriumzero_cluster_settings" "default" {
   timeout_read                    = "30s"
   timeout_write                   = "0s"
   tracing_sample_rate             = 0.0001
+  primary_color                   = "#6f43e7"
+  secondary_color                 = "#49aaa1"
+  logo_url                        = "https://example.com/logo.svg"
+  favicon_url                     = "https://example.com/favicon.ico"
+  error_message_first_paragraph   = "Request access to this application in the [IT portal](https://it.example.com/access)."
+  error_page_support_url          = "https://it.example.com/support"
+
+  timeouts {
+    update = "10m"
+  }
 }
 
 variable "pomerium_zero_identity_provider" {
@@ -0,0 +1,2 @@
+# Config snapshots can be imported by specifying the cluster ID and the snapshot ID, separated by a slash.
+terraform import pomeriumzero_config_snapshot.before_refactor bZPhcRUBcFwVlLCEPsSHMTxEqLR/qWeRtYuIoPaSdFgHjKlZxCv
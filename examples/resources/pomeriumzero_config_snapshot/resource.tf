@@ -0,0 +1,9 @@
+# Take a snapshot of the cluster configuration before refactoring policies
+resource "pomeriumzero_config_snapshot" "before_refactor" {
+  cluster_id  = pomeriumzero_cluster.example.id
+  name        = "before-policy-refactor"
+  description = "Configuration before the policy refactor"
+
+  # Set or change to restore the cluster configuration to this snapshot
+  # restore_trigger = "INC-1234"
+}
@@ -0,0 +1,2 @@
+# The directory provider can be imported by specifying the cluster id. Secrets are not returned by the API and have to be set in the configuration.
+terraform import pomeriumzero_idp_directory_provider.default bZPhcRUBcFwVlLCEPsSHMTxEqLR
@@ -0,0 +1,29 @@
+resource "pomeriumzero_idp_directory_provider" "default" {
+  cluster_id       = pomeriumzero_cluster.default.id
+  refresh_interval = "10m"
+  refresh_timeout  = "1m"
+
+  azure = {
+    client_id     = var.azure_directory_client_id
+    client_secret = var.azure_directory_client_secret
+    directory_id  = var.azure_directory_id
+  }
+}
+
+variable "azure_directory_client_id" {
+  sensitive   = false
+  description = "Client ID of the Azure application registration used for directory sync"
+  type        = string
+}
+
+variable "azure_directory_client_secret" {
+  sensitive   = true
+  description = "Client secret of the Azure application registration used for directory sync"
+  type        = string
+}
+
+variable "azure_directory_id" {
+  sensitive   = false
+  description = "Azure directory (tenant) ID"
+  type        = string
+}
@@ -0,0 +1,2 @@
+# Log exports can be imported by specifying the cluster ID and the log export ID, separated by a slash. Header values are not returned by the API and have to be set in the configuration.
+terraform import pomeriumzero_log_export.siem bZPhcRUBcFwVlLCEPsSHMTxEqLR/lXcVbNmQwErTyUiOpAsDfGh
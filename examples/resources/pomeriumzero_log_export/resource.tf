@@ -0,0 +1,17 @@
+resource "pomeriumzero_log_export" "siem" {
+  cluster_id = pomeriumzero_cluster.default.id
+  name       = "Splunk"
+  url        = "https://splunk.example.com:8088/services/collector/raw"
+  format     = "json"
+  log_types  = ["access", "authorize"]
+
+  headers = {
+    Authorization = "Splunk ${var.splunk_hec_token}"
+  }
+}
+
+variable "splunk_hec_token" {
+  sensitive   = true
+  description = "Splunk HTTP Event Collector token"
+  type        = string
+}
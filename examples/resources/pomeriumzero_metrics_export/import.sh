@@ -0,0 +1,2 @@
+# The metrics export can be imported by specifying the cluster id. Credentials are not returned by the API and have to be set in the configuration.
+terraform import pomeriumzero_metrics_export.default bZPhcRUBcFwVlLCEPsSHMTxEqLR
@@ -0,0 +1,15 @@
+resource "pomeriumzero_metrics_export" "default" {
+  cluster_id      = pomeriumzero_cluster.default.id
+  scrape_interval = "30s"
+
+  prometheus_remote_write = {
+    url          = "https://prometheus.example.com/api/v1/write"
+    bearer_token = var.prometheus_remote_write_token
+  }
+}
+
+variable "prometheus_remote_write_token" {
+  sensitive   = true
+  description = "Bearer token for the Prometheus remote-write endpoint"
+  type        = string
+}
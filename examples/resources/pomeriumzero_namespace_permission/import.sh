@@ -0,0 +1,2 @@
+# Namespace permissions can be imported by specifying the namespace ID and the permission ID, separated by a slash.
+terraform import pomeriumzero_namespace_permission.payments_team_lead nVbGtRfEdCwSxZaQpLoKiMjUnHy/pHgTfRdEsWaQzXcVbNmLkJi
@@ -0,0 +1,21 @@
+# Let the payments team manage routes and policies in their own namespace
+resource "pomeriumzero_namespace_permission" "payments_team_lead" {
+  namespace_id = var.payments_namespace_id
+  user_id      = var.payments_team_lead_user_id
+  permissions = [
+    "routes:read",
+    "routes:write",
+    "policies:read",
+    "policies:write",
+  ]
+}
+
+variable "payments_namespace_id" {
+  description = "ID of the namespace of the payments team"
+  type        = string
+}
+
+variable "payments_team_lead_user_id" {
+  description = "ID of the member leading the payments team"
+  type        = string
+}
@@ -0,0 +1,2 @@
+# Notification webhooks can be imported by specifying the webhook ID. The secret is not returned by the API and has to be set in the configuration.
+terraform import pomeriumzero_notification_webhook.slack dMrTyUvWxZaBcDeFgHiJkLmNoPq
@@ -0,0 +1,22 @@
+resource "pomeriumzero_notification_webhook" "slack" {
+  name   = "Slack #pomerium-alerts"
+  url    = var.slack_webhook_url
+  secret = var.slack_webhook_secret
+  events = [
+    "cluster.unhealthy",
+    "changeset.failed",
+    "certificate.expiring",
+  ]
+}
+
+variable "slack_webhook_url" {
+  sensitive   = true
+  description = "URL of the Slack incoming webhook"
+  type        = string
+}
+
+variable "slack_webhook_secret" {
+  sensitive   = true
+  description = "Secret used to sign the notifications"
+  type        = string
+}
@@ -0,0 +1,2 @@
+# Role assignments can be imported by specifying the role assignment ID.
+terraform import pomeriumzero_role_assignment.auditor cKqZtWbLmRfVnXoYpDsEhGuJiAc
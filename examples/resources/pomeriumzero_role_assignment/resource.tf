@@ -0,0 +1,22 @@
+# Give a member read-only access to the whole organization
+resource "pomeriumzero_role_assignment" "auditor" {
+  role    = "viewer"
+  user_id = var.auditor_user_id
+}
+
+# Let a CI service account manage routes and policies in a single namespace
+resource "pomeriumzero_role_assignment" "ci" {
+  role               = "editor"
+  service_account_id = var.ci_service_account_id
+  namespace_id       = pomeriumzero_cluster.default.namespace_id
+}
+
+variable "auditor_user_id" {
+  description = "ID of the member that is given read-only access"
+  type        = string
+}
+
+variable "ci_service_account_id" {
+  description = "ID of the service account used by CI"
+  type        = string
+}
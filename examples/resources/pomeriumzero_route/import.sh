@@ -1,2 +1,6 @@
 # Routes can be imported by specifying the route ID. You can see the route ID in the URL when viewing the policy in the Pomerium console.
 terraform import pomeriumzero_route.verify bRMWzWNvrZruhmMgashHJegGeGL
+
+# Routes can also be imported by specifying the namespace ID and the route ID, separated by a slash.
+# The import fails when the route is not in that namespace.
+terraform import pomeriumzero_route.verify bZPhcRUBcFwVlLCEPsSHMTxEqLR/bRMWzWNvrZruhmMgashHJegGeGL
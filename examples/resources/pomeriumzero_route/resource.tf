@@ -12,6 +12,12 @@ resource "pomeriumzero_route" "verify" {
   ]
 
   pass_identity_headers = true
+
+  # Fail the apply when the route is not reachable, e.g. because DNS has not propagated yet
+  wait_for_ready {
+    expected_status = 302
+    timeout         = "5m"
+  }
 }
 
 
@@ -45,4 +51,8 @@ resource "pomeriumzero_route" "kubernetes_api" {
   ]
   pass_identity_headers = true
   kubernetes_service_account_token = data.kubernetes_secret.k8s_api_service_account_token.data["token"]
+  # Bump to send the token again, e.g. after it was rotated outside of Terraform
+  kubernetes_service_account_token_version = "1"
+  # Guard the route against accidental deletion
+  deletion_protection = true
 }
@@ -0,0 +1,19 @@
+# Manage the routes of an open-source Pomerium configuration file
+resource "pomeriumzero_routes_bulk" "migrated" {
+  namespace_id = pomeriumzero_cluster.default.namespace_id
+  routes       = file("${path.module}/config.yaml")
+}
+
+# Routes can also be generated in Terraform and passed in as JSON
+resource "pomeriumzero_routes_bulk" "internal_apps" {
+  namespace_id = pomeriumzero_cluster.default.namespace_id
+  routes = jsonencode([
+    for app in ["grafana", "prometheus", "alertmanager"] : {
+      name             = app
+      from             = "https://${app}.example.com"
+      to               = ["http://${app}.monitoring.svc.cluster.local"]
+      allow_websockets = true
+      policy_ids       = [pomeriumzero_policy.allow_any_authenticated_user.id]
+    }
+  ])
+}
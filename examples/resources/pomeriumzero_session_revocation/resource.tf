@@ -0,0 +1,19 @@
+# Sign out a user whose credentials were compromised
+resource "pomeriumzero_session_revocation" "compromised_user" {
+  cluster_id = pomeriumzero_cluster.default.id
+  user_id    = var.compromised_user_id
+
+  triggers = {
+    incident = var.incident_id
+  }
+}
+
+variable "compromised_user_id" {
+  description = "ID of the user whose sessions are revoked"
+  type        = string
+}
+
+variable "incident_id" {
+  description = "Incident ticket; changing it revokes the sessions again"
+  type        = string
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ActivityLogDataSource{}
+
+// NewActivityLogDataSource creates a new ActivityLogDataSource.
+func NewActivityLogDataSource() datasource.DataSource {
+	return &ActivityLogDataSource{}
+}
+
+// ActivityLogDataSource defines the data source implementation.
+type ActivityLogDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ActivityLogDataSourceModel describes the data source data model.
+type ActivityLogDataSourceModel struct {
+	Since   types.String                `tfsdk:"since"`
+	Until   types.String                `tfsdk:"until"`
+	Actor   types.String                `tfsdk:"actor"`
+	Action  types.String                `tfsdk:"action"`
+	Entries []ActivityLogEntryDataModel `tfsdk:"entries"`
+}
+
+// ActivityLogEntryDataModel describes a single entry returned by the ActivityLogDataSource.
+type ActivityLogEntryDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Actor        types.String `tfsdk:"actor"`
+	Action       types.String `tfsdk:"action"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceID   types.String `tfsdk:"resource_id"`
+	Timestamp    types.String `tfsdk:"timestamp"`
+}
+
+// Metadata sets the data source type name for the ActivityLogDataSource.
+func (d *ActivityLogDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_activity_log"
+}
+
+// Schema defines the structure and attributes of the ActivityLogDataSource.
+func (d *ActivityLogDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Queries the activity log of the organization over a time window, e.g. for compliance reports. " +
+			"Use the `time_offset` resource of the `time` provider or `plantimestamp()` to compute a sliding window.",
+		Attributes: map[string]schema.Attribute{
+			"since": schema.StringAttribute{
+				MarkdownDescription: "The RFC 3339 timestamp at which the time window starts, e.g. `2025-01-01T00:00:00Z`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringIsRFC3339(),
+				},
+			},
+			"until": schema.StringAttribute{
+				MarkdownDescription: "The RFC 3339 timestamp at which the time window ends. When not set, the time window ends now.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsRFC3339(),
+				},
+			},
+			"actor": schema.StringAttribute{
+				MarkdownDescription: "Only return the entries of this actor, e.g. the email address of a member.",
+				Optional:            true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "Only return the entries of this action, e.g. `route.updated`.",
+				Optional:            true,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching entries, from oldest to newest.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the entry.",
+							Computed:            true,
+						},
+						"actor": schema.StringAttribute{
+							MarkdownDescription: "Who performed the action.",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "The action that was performed.",
+							Computed:            true,
+						},
+						"resource_type": schema.StringAttribute{
+							MarkdownDescription: "The type of the resource the action was performed on, e.g. `route`.",
+							Computed:            true,
+						},
+						"resource_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the resource the action was performed on.",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "The timestamp at which the action was performed.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ActivityLogDataSource.
+func (d *ActivityLogDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read queries the activity log, following the pagination cursor until all entries are fetched.
+func (d *ActivityLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ActivityLogDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	query.Set("since", data.Since.ValueString())
+	if !data.Until.IsNull() {
+		query.Set("until", data.Until.ValueString())
+	}
+	if !data.Actor.IsNull() {
+		query.Set("actor", data.Actor.ValueString())
+	}
+	if !data.Action.IsNull() {
+		query.Set("action", data.Action.ValueString())
+	}
+
+	data.Entries = []ActivityLogEntryDataModel{}
+	for {
+		activityLogURL := fmt.Sprintf("%s/organizations/%s/activity-log?%s", apiBaseURL, d.organizationID, query.Encode())
+
+		var page ActivityLogPage
+		if err := doAPIRequest(ctx, d.client, d.token, "GET", activityLogURL, nil, http.StatusOK, &page); err != nil {
+			resp.Diagnostics.AddError("Error fetching activity log", err.Error())
+			return
+		}
+
+		for _, entry := range page.Entries {
+			data.Entries = append(data.Entries, ActivityLogEntryDataModel{
+				ID:           types.StringValue(entry.ID),
+				Actor:        types.StringValue(entry.Actor),
+				Action:       types.StringValue(entry.Action),
+				ResourceType: stringValueOrNull(entry.ResourceType),
+				ResourceID:   stringValueOrNull(entry.ResourceID),
+				Timestamp:    types.StringValue(entry.Timestamp),
+			})
+		}
+
+		if page.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.NextCursor)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
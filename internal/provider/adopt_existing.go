@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// adoptExistingAttribute returns the schema of the adopt_existing attribute, which makes Create take
+// over an existing object when the API reports a conflict. The attribute is only stored in the
+// Terraform state.
+func adoptExistingAttribute(resourceName, match string) resource_schema.BoolAttribute {
+	return resource_schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("If set to `true` and the API rejects the creation of the %s because it already exists, "+
+			"Terraform takes the existing %s with %s in the same namespace under management and updates it to match the configuration, "+
+			"instead of failing. Only has an effect on creation.", resourceName, resourceName, match),
+	}
+}
+
+// isConflict reports whether err is an API error reporting that the object already exists.
+func isConflict(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
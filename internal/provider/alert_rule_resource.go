@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AlertRuleResource{}
+var _ resource.ResourceWithImportState = &AlertRuleResource{}
+var _ resource.ResourceWithValidateConfig = &AlertRuleResource{}
+
+// alertRuleTypes lists the conditions an alert rule can watch for.
+var alertRuleTypes = []string{"cluster_offline", "certificate_expiry", "changeset_failure"}
+
+// NewAlertRuleResource creates a new AlertRuleResource.
+func NewAlertRuleResource() resource.Resource {
+	return &AlertRuleResource{}
+}
+
+// AlertRuleResource defines the resource implementation.
+type AlertRuleResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// AlertRuleResourceModel describes the resource data model.
+type AlertRuleResourceModel struct {
+	ID                     types.String  `tfsdk:"id"`
+	Name                   types.String  `tfsdk:"name"`
+	Type                   types.String  `tfsdk:"type"`
+	ClusterID              types.String  `tfsdk:"cluster_id"`
+	Threshold              durationValue `tfsdk:"threshold"`
+	NotificationWebhookIDs types.Set     `tfsdk:"notification_webhook_ids"`
+	Emails                 types.Set     `tfsdk:"emails"`
+	Enabled                types.Bool    `tfsdk:"enabled"`
+}
+
+// Metadata sets the resource type name for the AlertRuleResource.
+func (r *AlertRuleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_rule"
+}
+
+// Schema defines the structure and attributes of the AlertRuleResource.
+func (r *AlertRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an alert rule that notifies when a cluster goes offline, a certificate is about to expire or a changeset fails to apply. " +
+			"At least one of `notification_webhook_ids` or `emails` must be configured.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the alert rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the alert rule.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The condition the alert rule watches for. One of `cluster_offline`, `certificate_expiry` or `changeset_failure`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf(alertRuleTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster the alert rule applies to. When not set, the alert rule applies to all clusters in the organization.",
+				Optional:            true,
+			},
+			"threshold": schema.StringAttribute{
+				CustomType: durationType{},
+				MarkdownDescription: "A duration such as `5m` or `720h`. For `cluster_offline` rules, how long a cluster has to be offline before the alert fires. " +
+					"For `certificate_expiry` rules, how long before a certificate expires the alert fires. " +
+					"Required for these types, and not supported for `changeset_failure` rules.",
+				Optional: true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"notification_webhook_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the notification webhooks the alert is sent to.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"emails": schema.SetAttribute{
+				MarkdownDescription: "The email addresses the alert is sent to.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the alert rule is evaluated. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that the threshold matches the type of the alert rule and that the alert is sent somewhere.
+func (r *AlertRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AlertRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Type.IsUnknown() && !data.Threshold.IsUnknown() {
+		switch {
+		case data.Type.ValueString() == "changeset_failure" && !data.Threshold.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("threshold"),
+				"Invalid Alert Rule Configuration",
+				"threshold is not supported for changeset_failure alert rules.",
+			)
+		case data.Type.ValueString() != "changeset_failure" && data.Threshold.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root("threshold"),
+				"Invalid Alert Rule Configuration",
+				fmt.Sprintf("threshold is required for %s alert rules.", data.Type.ValueString()),
+			)
+		}
+	}
+
+	// The notification targets may not be known until apply
+	if data.NotificationWebhookIDs.IsUnknown() || data.Emails.IsUnknown() {
+		return
+	}
+
+	if len(data.NotificationWebhookIDs.Elements()) == 0 && len(data.Emails.Elements()) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Alert Rule Configuration",
+			"At least one of notification_webhook_ids or emails must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the AlertRuleResource.
+func (r *AlertRuleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create creates the alert rule.
+func (r *AlertRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AlertRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleReq, diags := createAlertRuleRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/alert-rules", apiBaseURL, r.organizationID)
+
+	var rule AlertRule
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, ruleReq, http.StatusCreated, &rule); err != nil {
+		resp.Diagnostics.AddError("Error creating alert rule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertRuleResourceModel(ctx, &plan, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the alert rule.
+func (r *AlertRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AlertRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := r.getAlertRule(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading alert rule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertRuleResourceModel(ctx, &state, rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the alert rule.
+func (r *AlertRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan AlertRuleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ruleReq, diags := createAlertRuleRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/alert-rules/%s", apiBaseURL, r.organizationID, plan.ID.ValueString())
+
+	var rule AlertRule
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, ruleReq, http.StatusOK, &rule); err != nil {
+		resp.Diagnostics.AddError("Error updating alert rule", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateAlertRuleResourceModel(ctx, &plan, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the alert rule.
+func (r *AlertRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AlertRuleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/alert-rules/%s", apiBaseURL, r.organizationID, state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting alert rule", err.Error())
+		return
+	}
+}
+
+// ImportState imports an alert rule by its ID.
+func (r *AlertRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	rule, err := r.getAlertRule(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing alert rule", fmt.Sprintf("Unable to read alert rule %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := AlertRuleResourceModel{
+		NotificationWebhookIDs: types.SetNull(types.StringType),
+		Emails:                 types.SetNull(types.StringType),
+	}
+	resp.Diagnostics.Append(updateAlertRuleResourceModel(ctx, &state, rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getAlertRule fetches an alert rule by its ID.
+func (r *AlertRuleResource) getAlertRule(ctx context.Context, ruleID string) (*AlertRule, error) {
+	url := fmt.Sprintf("%s/organizations/%s/alert-rules/%s", apiBaseURL, r.organizationID, ruleID)
+
+	var rule AlertRule
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &rule); err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// createAlertRuleRequest creates the API request body from the AlertRuleResourceModel.
+func createAlertRuleRequest(ctx context.Context, model AlertRuleResourceModel) (AlertRule, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rule := AlertRule{
+		Name:      model.Name.ValueString(),
+		Type:      model.Type.ValueString(),
+		ClusterID: model.ClusterID.ValueString(),
+		Threshold: model.Threshold.ValueString(),
+		Enabled:   model.Enabled.ValueBool(),
+	}
+
+	if !model.NotificationWebhookIDs.IsNull() {
+		diags.Append(model.NotificationWebhookIDs.ElementsAs(ctx, &rule.NotificationWebhookIDs, false)...)
+	}
+	if !model.Emails.IsNull() {
+		diags.Append(model.Emails.ElementsAs(ctx, &rule.Emails, false)...)
+	}
+
+	return rule, diags
+}
+
+// updateAlertRuleResourceModel updates the model with the alert rule returned by the API.
+func updateAlertRuleResourceModel(ctx context.Context, model *AlertRuleResourceModel, rule *AlertRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(rule.ID)
+	model.Name = types.StringValue(rule.Name)
+	model.Type = types.StringValue(rule.Type)
+	model.ClusterID = stringValueOrNull(rule.ClusterID)
+	model.Threshold = durationValueOrNull(rule.Threshold)
+	model.Enabled = types.BoolValue(rule.Enabled)
+
+	// Empty sets are returned as null, so that an omitted attribute does not show a diff
+	if len(rule.NotificationWebhookIDs) > 0 || !model.NotificationWebhookIDs.IsNull() {
+		webhookIDs, d := types.SetValueFrom(ctx, types.StringType, rule.NotificationWebhookIDs)
+		diags.Append(d...)
+		model.NotificationWebhookIDs = webhookIDs
+	}
+	if len(rule.Emails) > 0 || !model.Emails.IsNull() {
+		emails, d := types.SetValueFrom(ctx, types.StringType, rule.Emails)
+		diags.Append(d...)
+		model.Emails = emails
+	}
+
+	return diags
+}
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// errNotFound is returned by doAPIRequest when the requested object does not exist.
+var errNotFound = errors.New("not found")
+
+// isNotFoundStatus reports whether a response status code means that the requested object
+// does not exist, or no longer exists. Read treats such objects as deleted outside of Terraform.
+func isNotFoundStatus(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusGone
+}
+
+// doAPIRequest sends an authenticated request to the Pomerium Zero API and checks that the
+// response has the expected status code. The body, if not nil, is sent as JSON, and the JSON
+// response body is decoded into result, if not nil.
+func doAPIRequest(ctx context.Context, client *http.Client, token, method, url string, body interface{}, expectedStatus int, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+		// Bodies hold secrets such as client secrets and tokens, which must not reach the logs
+		log.Printf("[DEBUG] %s %s request body: %s", method, url, redactJSON(jsonBody))
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	// Do not send requests for operations that were already cancelled or timed out
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s %s: %w", method, url, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read the body into a pooled buffer, which decoding copies from. A json.Decoder reading the body
+	// directly does not save the copy, as it buffers each value in full before decoding it, into a
+	// buffer of its own that is not reused across requests: see BenchmarkDecodeResponse.
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	responseBody := buf.Bytes()
+
+	log.Printf("[DEBUG] %s %s response status: %d", method, url, resp.StatusCode)
+
+	if isNotFoundStatus(resp.StatusCode) && resp.StatusCode != expectedStatus {
+		return fmt.Errorf("%s %s: %w", method, url, errNotFound)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return newAPIError(resp.StatusCode, responseBody)
+	}
+
+	if result != nil && len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, result); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// apiError is returned when the API responds with an unexpected status code. For rejected requests,
+// it holds the fields the API reported as invalid, if any.
+type apiError struct {
+	StatusCode int
+	Body       string
+	Fields     []apiFieldError
+}
+
+// apiFieldError is a validation error the API reported for a single field of a request.
+type apiFieldError struct {
+	// Field is the name of the field in the API, e.g. timeoutRead
+	Field   string
+	Message string
+}
+
+// Error returns the status code and the response body.
+func (e *apiError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d. Response body: %s", e.StatusCode, e.Body)
+}
+
+// newAPIError creates an apiError from a response, parsing the field errors of rejected requests.
+func newAPIError(statusCode int, body []byte) *apiError {
+	err := &apiError{StatusCode: statusCode, Body: string(body)}
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity {
+		err.Fields = parseAPIFieldErrors(body)
+	}
+	return err
+}
+
+// parseAPIFieldErrors extracts field errors from the body of a rejected request. The API reports them
+// either as a list of details or field violations, each naming a field, or as a map from field to message.
+func parseAPIFieldErrors(body []byte) []apiFieldError {
+	type detail struct {
+		Field       string `json:"field"`
+		Path        string `json:"path"`
+		Message     string `json:"message"`
+		Description string `json:"description"`
+	}
+	var response struct {
+		Details         []detail        `json:"details"`
+		FieldViolations []detail        `json:"fieldViolations"`
+		Errors          json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil
+	}
+
+	details := append(response.Details, response.FieldViolations...)
+	var listed []detail
+	var mapped map[string]string
+	if json.Unmarshal(response.Errors, &listed) == nil {
+		details = append(details, listed...)
+	} else if json.Unmarshal(response.Errors, &mapped) == nil {
+		for _, field := range sortedStringKeys(mapped) {
+			details = append(details, detail{Field: field, Message: mapped[field]})
+		}
+	}
+
+	var fields []apiFieldError
+	for _, d := range details {
+		field := d.Field
+		if field == "" {
+			field = d.Path
+		}
+		message := d.Message
+		if message == "" {
+			message = d.Description
+		}
+		if field != "" && message != "" {
+			fields = append(fields, apiFieldError{Field: field, Message: message})
+		}
+	}
+	return fields
+}
+
+// apiErrorDiagnostics converts an error of a create or update request into diagnostics. Field errors
+// reported by the API are attached to the matching attribute of the plan, so that Terraform points at
+// the offending line of the configuration. Other errors are reported on the resource as a whole.
+func apiErrorDiagnostics(ctx context.Context, summary string, err error, plan tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || len(apiErr.Fields) == 0 {
+		return errorDiagnostics(summary, err.Error(), err)
+	}
+
+	unmapped := false
+	for _, field := range apiErr.Fields {
+		attributePath := path.Root(apiFieldAttributeName(field.Field))
+
+		var value attr.Value
+		if plan.GetAttribute(ctx, attributePath, &value).HasError() {
+			// Not an attribute of this resource, so it is reported with the rest of the error
+			unmapped = true
+			continue
+		}
+		diags.AddAttributeError(attributePath, summary, fmt.Sprintf("The API rejected this value: %s", field.Message))
+	}
+	if unmapped {
+		diags.AddError(summary, err.Error())
+	}
+	return diags
+}
+
+// errorDiagnostics returns an error diagnostic with the given summary and detail, unless err means that
+// the operation was cancelled or ran out of time. Those are reported with a distinct summary, as they
+// are not caused by the API, and the object may have been changed before the operation stopped.
+func errorDiagnostics(summary, detail string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	switch {
+	case errors.Is(err, context.Canceled):
+		diags.AddError("Operation Cancelled",
+			"The operation was cancelled before it completed, e.g. because Terraform was interrupted. "+
+				"The object may have been changed partially; run terraform plan to review its current state.\n\n"+summary+": "+detail)
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError("Operation Timed Out",
+			"The operation did not complete within its timeout. "+
+				"The object may have been changed partially; run terraform plan to review its current state.\n\n"+summary+": "+detail)
+	default:
+		diags.AddError(summary, detail)
+	}
+	return diags
+}
+
+// apiFieldAttributeName returns the name of the attribute for a field named by the API, e.g.
+// timeout_read for timeoutRead or to for to[0]. Nested fields map to their top-level attribute.
+func apiFieldAttributeName(field string) string {
+	if i := strings.IndexAny(field, ".["); i >= 0 {
+		field = field[:i]
+	}
+
+	var name strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				name.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
+
+// sortedStringKeys returns the keys of a map in sorted order.
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiMetrics counts the API requests of all provider instances of the process. Terraform starts a
+// provider process for each command, so the summary logged when the process stops covers one plan
+// or apply.
+var apiMetrics = &apiMetricsRecorder{endpoints: make(map[string]*endpointMetrics)}
+
+// apiMetricsRecorder aggregates requests by endpoint.
+type apiMetricsRecorder struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+// endpointMetrics are the metrics of a single endpoint, i.e. a method and a path with IDs left out.
+type endpointMetrics struct {
+	requests    int
+	retries     int
+	errors      int
+	rateLimited int
+	latency     time.Duration
+}
+
+// endpoint returns the metrics of the endpoint of a request. The caller must hold m.mu.
+func (m *apiMetricsRecorder) endpoint(req *http.Request) *endpointMetrics {
+	endpoint := req.Method + " " + endpointPath(req.URL.Path)
+	metrics, ok := m.endpoints[endpoint]
+	if !ok {
+		metrics = &endpointMetrics{}
+		m.endpoints[endpoint] = metrics
+	}
+	return metrics
+}
+
+// record adds a completed request, including all its attempts, to the metrics of its endpoint. Failed
+// requests and responses with an error status count as errors, and 429 responses also count as rate
+// limited. The latency includes the delays between attempts.
+func (m *apiMetricsRecorder) record(req *http.Request, resp *http.Response, err error, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := m.endpoint(req)
+	metrics.requests++
+	metrics.latency += latency
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		metrics.errors++
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		metrics.rateLimited++
+	}
+}
+
+// recordRetry adds an attempt that failed with a transient error, after which the request is resent,
+// to the metrics of its endpoint. Attempts answered with 429 also count as rate limited.
+func (m *apiMetricsRecorder) recordRetry(req *http.Request, resp *http.Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	metrics := m.endpoint(req)
+	metrics.retries++
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		metrics.rateLimited++
+	}
+}
+
+// summary returns a single line per endpoint, ordered by the number of requests, after a total line.
+// It returns an empty string when no request was made.
+func (m *apiMetricsRecorder) summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.endpoints) == 0 {
+		return ""
+	}
+
+	endpoints := make([]string, 0, len(m.endpoints))
+	var total endpointMetrics
+	for endpoint, metrics := range m.endpoints {
+		endpoints = append(endpoints, endpoint)
+		total.requests += metrics.requests
+		total.retries += metrics.retries
+		total.errors += metrics.errors
+		total.rateLimited += metrics.rateLimited
+		total.latency += metrics.latency
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		a, b := m.endpoints[endpoints[i]], m.endpoints[endpoints[j]]
+		if a.requests != b.requests {
+			return a.requests > b.requests
+		}
+		return endpoints[i] < endpoints[j]
+	})
+
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "%d requests, %d retries, %d errors, %d rate limited, %s total latency",
+		total.requests, total.retries, total.errors, total.rateLimited, total.latency.Round(time.Millisecond))
+	for _, endpoint := range endpoints {
+		metrics := m.endpoints[endpoint]
+		fmt.Fprintf(&summary, "\n  %s: %d requests, %d retries, %d errors, %d rate limited, %s total latency",
+			endpoint, metrics.requests, metrics.retries, metrics.errors, metrics.rateLimited, metrics.latency.Round(time.Millisecond))
+	}
+	return summary.String()
+}
+
+// apiCollections holds the names of the collections of the API whose objects are addressed by ID,
+// as in /organizations/{id}/routes/{id}.
+var apiCollections = map[string]bool{
+	"access-tokens":         true,
+	"alert-rules":           true,
+	"api-tokens":            true,
+	"clusters":              true,
+	"log-exports":           true,
+	"namespaces":            true,
+	"notification-webhooks": true,
+	"organizations":         true,
+	"permissions":           true,
+	"policies":              true,
+	"role-assignments":      true,
+	"routes":                true,
+	"snapshots":             true,
+}
+
+// endpointPath replaces the IDs in a path by a placeholder, so that requests for different objects of
+// the same kind count towards the same endpoint. The segment following the name of a collection is
+// taken for an ID, whatever its format.
+func endpointPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if apiCollections[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// LogAPIMetrics logs a summary of the API requests made by the process, if any. It is called when the
+// provider server stops.
+func LogAPIMetrics() {
+	if summary := apiMetrics.summary(); summary != "" {
+		log.Printf("[INFO] Pomerium Zero API usage: %s", summary)
+	}
+}
+
+// metricsTransport records the endpoint, outcome and latency of each request in apiMetrics. It sits
+// above retryTransport, so that a request counts once however many attempts it takes, while
+// retryTransport records the retries.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sends the request and records it.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	apiMetrics.record(req, resp, err, time.Since(start))
+	return resp, err
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resetAPIMetrics replaces the process wide metrics for the duration of a test.
+func resetAPIMetrics(t *testing.T) {
+	t.Helper()
+
+	previous := apiMetrics
+	apiMetrics = &apiMetricsRecorder{endpoints: make(map[string]*endpointMetrics)}
+	t.Cleanup(func() { apiMetrics = previous })
+}
+
+func TestMetricsCountRetriesSeparately(t *testing.T) {
+	resetAPIMetrics(t)
+
+	server, _ := newRetryTestServer(t, respondWith(http.StatusTooManyRequests), respondWith(http.StatusServiceUnavailable))
+	client := &http.Client{Transport: &metricsTransport{next: &retryTransport{next: http.DefaultTransport, attemptTimeout: time.Second}}}
+
+	resp, err := client.Get(server.URL + "/api/v0/organizations/org1/routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	summary := apiMetrics.summary()
+	want := "1 requests, 2 retries, 0 errors, 1 rate limited"
+	if !strings.HasPrefix(summary, want) {
+		t.Errorf("expected the summary to start with %q, got:\n%s", want, summary)
+	}
+	if !strings.Contains(summary, "GET /api/v0/organizations/{id}/routes: 1 requests, 2 retries") {
+		t.Errorf("expected the retries to be reported per endpoint, got:\n%s", summary)
+	}
+}
+
+func TestEndpointPath(t *testing.T) {
+	tests := map[string]string{
+		"/api/v0/organizations":                    "/api/v0/organizations",
+		"/api/v0/organizations/org1/routes":        "/api/v0/organizations/{id}/routes",
+		"/api/v0/organizations/org1/routes/route2": "/api/v0/organizations/{id}/routes/{id}",
+		// IDs without digits are replaced as well
+		"/api/v0/organizations/acme/routes/app":                     "/api/v0/organizations/{id}/routes/{id}",
+		"/api/v0/organizations/acme/clusters/prod/settings":         "/api/v0/organizations/{id}/clusters/{id}/settings",
+		"/api/v0/organizations/acme/clusters/prod/snapshots/s1":     "/api/v0/organizations/{id}/clusters/{id}/snapshots/{id}",
+		"/api/v0/organizations/acme/clusters/prod/changesets/apply": "/api/v0/organizations/{id}/clusters/{id}/changesets/apply",
+		"/api/v0/organizations/":                                    "/api/v0/organizations/",
+		"/api/v0/token/oidc":                                        "/api/v0/token/oidc",
+	}
+	for path, want := range tests {
+		if got := endpointPath(path); got != want {
+			t.Errorf("endpointPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// largeOrganizationRoutes returns the JSON list of routes of a large organization.
+func largeOrganizationRoutes(count int) []byte {
+	routes := make([]map[string]interface{}, count)
+	for i := range routes {
+		routes[i] = map[string]interface{}{
+			"id":                  fmt.Sprintf("route%d", i),
+			"name":                fmt.Sprintf("app-%d", i),
+			"from":                fmt.Sprintf("https://app-%d.example.com", i),
+			"to":                  []string{fmt.Sprintf("http://app-%d.internal:8080", i)},
+			"namespaceId":         "namespace1",
+			"policyIds":           []string{"policy1", "policy2"},
+			"passIdentityHeaders": true,
+			"allowWebsockets":     false,
+			"timeoutRead":         "30s",
+		}
+	}
+	body, err := json.Marshal(routes)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
+
+// BenchmarkDecodeResponse compares ways of decoding a large list response: reading it into a new
+// buffer, into a pooled buffer as doAPIRequest does, and streaming it through a json.Decoder.
+func TestDoAPIRequestDoesNotLogSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	body := map[string]interface{}{
+		"name":   "directory",
+		"params": map[string]string{"clientSecret": "secret-client-secret", "jsonKey": "secret-json-key", "apiKey": "secret-api-key"},
+	}
+	if err := doAPIRequest(context.Background(), server.Client(), "secret-bearer-token", http.MethodPost, server.URL, body, http.StatusCreated, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(logs.String(), `"name":"directory"`) {
+		t.Errorf("expected the request body to be logged, got:\n%s", logs.String())
+	}
+	for _, secret := range []string{"secret-client-secret", "secret-json-key", "secret-api-key", "secret-bearer-token"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("expected %q to be redacted from the logs, got:\n%s", secret, logs.String())
+		}
+	}
+}
+
+func BenchmarkDecodeResponse(b *testing.B) {
+	body := largeOrganizationRoutes(1000)
+
+	b.Run("ReadAll", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			data, err := io.ReadAll(bytes.NewReader(body))
+			if err != nil {
+				b.Fatal(err)
+			}
+			var routes []map[string]interface{}
+			if err := json.Unmarshal(data, &routes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PooledBuffer", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			buf := getBuffer()
+			if _, err := buf.ReadFrom(bytes.NewReader(body)); err != nil {
+				b.Fatal(err)
+			}
+			var routes []map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &routes); err != nil {
+				b.Fatal(err)
+			}
+			putBuffer(buf)
+		}
+	})
+
+	b.Run("StreamDecoder", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(body)))
+		for i := 0; i < b.N; i++ {
+			var routes []map[string]interface{}
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(&routes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkDoAPIRequest lists the routes of a simulated large organization through the shared
+// transport, in parallel as Terraform does, and through a transport without the tuned pool of idle
+// connections.
+func BenchmarkDoAPIRequest(b *testing.B) {
+	body := largeOrganizationRoutes(500)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	// The debug logs of each request would dominate the measurement
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	transports := map[string]http.RoundTripper{
+		"SharedTransport":  sharedTransport,
+		"DefaultTransport": http.DefaultTransport.(*http.Transport).Clone(),
+	}
+	for name, transport := range transports {
+		b.Run(name, func(b *testing.B) {
+			client := &http.Client{Transport: transport}
+			b.ReportAllocs()
+			b.SetParallelism(4)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					var routes []map[string]interface{}
+					if err := doAPIRequest(context.Background(), client, "token", "GET", server.URL, nil, http.StatusOK, &routes); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
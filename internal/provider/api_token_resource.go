@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APITokenResource{}
+var _ resource.ResourceWithImportState = &APITokenResource{}
+
+// NewAPITokenResource creates a new APITokenResource.
+func NewAPITokenResource() resource.Resource {
+	return &APITokenResource{}
+}
+
+// APITokenResource defines the resource implementation.
+type APITokenResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// APITokenResourceModel describes the resource data model.
+type APITokenResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Role        types.String `tfsdk:"role"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Token       types.String `tfsdk:"token"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	LastUsedAt  types.String `tfsdk:"last_used_at"`
+}
+
+// Metadata sets the resource type name for the APITokenResource.
+func (r *APITokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+// Schema defines the structure and attributes of the APITokenResource.
+func (r *APITokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Pomerium Zero organization API token, e.g. for automation. " +
+			"The token secret is only returned by the API when the token is created, and is stored in the Terraform state, " +
+			"so the state must be protected accordingly. Tokens that expire or are revoked outside of Terraform are created again on the next apply. " +
+			"Destroying the resource revokes the token.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the API token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the API token.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role of the API token. One of `admin`, `editor` or `viewer`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf(roles...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace the API token is limited to. When not set, the token is valid for the whole organization.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The RFC 3339 timestamp at which the API token expires, e.g. `2025-12-31T00:00:00Z`. " +
+					"When not set, the token does not expire. Use the `time_rotating` resource of the `time` provider to rotate the token regularly.",
+				Optional: true,
+				Validators: []validator.String{
+					stringIsRFC3339(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API token secret.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp at which the API token was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_used_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp at which the API token was last used.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the APITokenResource.
+func (r *APITokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create mints the API token.
+func (r *APITokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan APITokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/api-tokens", apiBaseURL, r.organizationID)
+	tokenReq := APIToken{
+		Name:        plan.Name.ValueString(),
+		Role:        plan.Role.ValueString(),
+		NamespaceID: plan.NamespaceID.ValueString(),
+		ExpiresAt:   plan.ExpiresAt.ValueString(),
+	}
+
+	var apiToken APIToken
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, tokenReq, http.StatusCreated, &apiToken); err != nil {
+		resp.Diagnostics.AddError("Error creating API token", err.Error())
+		return
+	}
+
+	plan.Token = types.StringValue(apiToken.Token)
+	updateAPITokenResourceModel(&plan, &apiToken)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the API token. Tokens that were revoked or have expired are removed from the state,
+// so that they are created again.
+func (r *APITokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state APITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiToken, err := r.getAPIToken(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading API token", err.Error())
+		return
+	}
+
+	if apiToken.Revoked {
+		log.Printf("[INFO] API token %s was revoked, removing it from the state", apiToken.ID)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, apiToken.ExpiresAt); err == nil && !expiresAt.After(time.Now()) {
+		log.Printf("[INFO] API token %s expired at %s, removing it from the state", apiToken.ID, apiToken.ExpiresAt)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	updateAPITokenResourceModel(&state, apiToken)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, since every configurable attribute change replaces the resource.
+func (r *APITokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan APITokenResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete revokes the API token.
+func (r *APITokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state APITokenResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/api-tokens/%s", apiBaseURL, r.organizationID, state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Error revoking API token", err.Error())
+		return
+	}
+}
+
+// ImportState imports an API token by its ID. The token secret cannot be imported.
+func (r *APITokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	apiToken, err := r.getAPIToken(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing API token", fmt.Sprintf("Unable to read API token %s, error: %s", req.ID, err))
+		return
+	}
+
+	// The token itself is only returned when the API token is created
+	state := APITokenResourceModel{Token: types.StringNull()}
+	updateAPITokenResourceModel(&state, apiToken)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getAPIToken fetches an API token by its ID.
+func (r *APITokenResource) getAPIToken(ctx context.Context, tokenID string) (*APIToken, error) {
+	url := fmt.Sprintf("%s/organizations/%s/api-tokens/%s", apiBaseURL, r.organizationID, tokenID)
+
+	var apiToken APIToken
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &apiToken); err != nil {
+		return nil, err
+	}
+
+	return &apiToken, nil
+}
+
+// updateAPITokenResourceModel updates the model with the API token returned by the API.
+// The token secret is only returned on creation, so it is not updated.
+func updateAPITokenResourceModel(model *APITokenResourceModel, apiToken *APIToken) {
+	model.ID = types.StringValue(apiToken.ID)
+	model.Name = types.StringValue(apiToken.Name)
+	model.Role = types.StringValue(apiToken.Role)
+	model.NamespaceID = stringValueOrNull(apiToken.NamespaceID)
+	if !sameTimestamp(model.ExpiresAt.ValueString(), apiToken.ExpiresAt) {
+		model.ExpiresAt = stringValueOrNull(apiToken.ExpiresAt)
+	}
+	model.CreatedAt = stringValueOrNull(apiToken.CreatedAt)
+	model.LastUsedAt = stringValueOrNull(apiToken.LastUsedAt)
+}
+
+// sameTimestamp reports whether both strings are RFC 3339 timestamps of the same instant,
+// e.g. "2025-01-31T01:00:00+01:00" and "2025-01-31T00:00:00Z".
+func sameTimestamp(a, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339, a)
+	timeB, errB := time.Parse(time.RFC3339, b)
+	return errA == nil && errB == nil && timeA.Equal(timeB)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiURLEnvVar names the environment variable holding the URL of the API, used when api_url is not set.
+const apiURLEnvVar = "POMERIUMZERO_API_URL"
+
+// parseAPIURL parses the URL that the API is served at, e.g. of a staging environment or a mock server.
+// Endpoints keep their path below it, e.g. /api/v0/token.
+func parseAPIURL(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("the API URL must be an absolute http or https URL such as %q, got: %q", consoleURL, value)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("the API URL must not have a query or fragment, got: %q", value)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u, nil
+}
+
+// apiURLTransport sends the requests that the provider builds for the default API URL, consoleURL,
+// to another URL. The endpoints are all built from apiBaseURL, so they move along.
+type apiURLTransport struct {
+	next http.RoundTripper
+	base *url.URL
+}
+
+// newAPIURLTransport returns a transport for the API URL, or next itself when it is consoleURL.
+func newAPIURLTransport(next http.RoundTripper, apiURL string) (http.RoundTripper, error) {
+	if apiURL == "" || strings.TrimSuffix(apiURL, "/") == consoleURL {
+		return next, nil
+	}
+
+	base, err := parseAPIURL(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	return &apiURLTransport{next: next, base: base}, nil
+}
+
+// RoundTrip rewrites the URL of requests to the default API URL.
+func (t *apiURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme+"://"+req.URL.Host != consoleURL {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.base.Scheme
+	req.URL.Host = t.base.Host
+	req.URL.Path = t.base.Path + req.URL.Path
+	req.URL.RawPath = ""
+	req.Host = ""
+	return t.next.RoundTrip(req)
+}
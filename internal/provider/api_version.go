@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// apiVersion is the version of the API the provider is written against. All endpoints are built
+// from apiBaseURL, which holds this version.
+const apiVersion = "v0"
+
+// apiVersionShim adapts the requests and responses of the provider, which follow apiVersion, to
+// another version of the API. Either function can be nil when the version does not change the
+// payloads in that direction.
+type apiVersionShim struct {
+	// request converts the body of a request to path, given relative to the base URL of the version.
+	request func(method, path string, body []byte) ([]byte, error)
+	// response converts the body of a response from path back to the format of apiVersion.
+	response func(method, path string, body []byte) ([]byte, error)
+}
+
+// apiVersionShims holds the versions of the API that api_version accepts, with the shim converting
+// the payloads of each. apiVersion is the only one until Pomerium Zero publishes another.
+var apiVersionShims = map[string]apiVersionShim{
+	apiVersion: {},
+}
+
+// supportedAPIVersions returns the versions of the API that api_version accepts, in order.
+func supportedAPIVersions() []string {
+	versions := make([]string, 0, len(apiVersionShims))
+	for version := range apiVersionShims {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// apiVersionTransport sends the requests of the provider to another version of the API, converting
+// their payloads with the shim of the version.
+type apiVersionTransport struct {
+	next    http.RoundTripper
+	version string
+	shim    apiVersionShim
+}
+
+// newAPIVersionTransport returns a transport for the API version, or next itself when it is apiVersion.
+func newAPIVersionTransport(next http.RoundTripper, version string) (http.RoundTripper, error) {
+	if version == "" || version == apiVersion {
+		return next, nil
+	}
+
+	shim, ok := apiVersionShims[version]
+	if !ok {
+		return nil, fmt.Errorf("version %q of the Pomerium Zero API is not supported by this provider, supported versions are: %s",
+			version, strings.Join(supportedAPIVersions(), ", "))
+	}
+	return &apiVersionTransport{next: next, version: version, shim: shim}, nil
+}
+
+// RoundTrip rewrites the URL and body of requests to apiVersion, and the body of their responses.
+func (t *apiVersionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path, ok := strings.CutPrefix(req.URL.Path, "/api/"+apiVersion)
+	if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.URL.Path = "/api/" + t.version + path
+	req.URL.RawPath = ""
+
+	if t.shim.request != nil && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		if body, err = t.shim.request(req.Method, path, body); err != nil {
+			return nil, fmt.Errorf("error converting request to API version %s: %w", t.version, err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || t.shim.response == nil || resp.StatusCode >= http.StatusBadRequest {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if body, err = t.shim.response(req.Method, path, body); err != nil {
+		return nil, fmt.Errorf("error converting response from API version %s: %w", t.version, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIVersionTransport(t *testing.T) {
+	for _, version := range []string{"", apiVersion} {
+		if transport, err := newAPIVersionTransport(http.DefaultTransport, version); err != nil || transport != http.DefaultTransport {
+			t.Errorf("expected version %q to use the transport as is, got %v, %v", version, transport, err)
+		}
+	}
+
+	if _, err := newAPIVersionTransport(http.DefaultTransport, "v9"); err == nil || !strings.Contains(err.Error(), apiVersion) {
+		t.Errorf("expected an unsupported version to be rejected, got: %v", err)
+	}
+}
+
+func TestAPIVersionTransportAppliesShim(t *testing.T) {
+	apiVersionShims["v9"] = apiVersionShim{
+		request: func(method, path string, body []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(body), `"from"`, `"source"`)), nil
+		},
+		response: func(method, path string, body []byte) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(body), `"source"`, `"from"`)), nil
+		},
+	}
+	t.Cleanup(func() { delete(apiVersionShims, "v9") })
+
+	transport, err := newAPIVersionTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/api/v9/organizations/org-1/routes" {
+			t.Errorf("expected the request to be sent to version v9, got %s", req.URL.Path)
+		}
+		body, _ := io.ReadAll(req.Body)
+		if string(body) != `{"source":"https://app.example.com"}` {
+			t.Errorf("expected the request body to be converted, got %s", body)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}), "v9")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, apiBaseURL+"/organizations/org-1/routes", strings.NewReader(`{"from":"https://app.example.com"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"from":"https://app.example.com"}` {
+		t.Errorf("expected the response body to be converted back, got %s", body)
+	}
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CertificatesDataSource{}
+
+// NewCertificatesDataSource creates a new CertificatesDataSource.
+func NewCertificatesDataSource() datasource.DataSource {
+	return &CertificatesDataSource{}
+}
+
+// CertificatesDataSource defines the data source implementation.
+type CertificatesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// CertificatesDataSourceModel describes the data source data model.
+type CertificatesDataSourceModel struct {
+	ClusterID     types.String           `tfsdk:"cluster_id"`
+	ExpiresWithin types.String           `tfsdk:"expires_within"`
+	Certificates  []CertificateDataModel `tfsdk:"certificates"`
+}
+
+// CertificateDataModel describes a single certificate returned by the CertificatesDataSource.
+type CertificateDataModel struct {
+	ID        types.String   `tfsdk:"id"`
+	Domains   []types.String `tfsdk:"domains"`
+	Issuer    types.String   `tfsdk:"issuer"`
+	NotBefore types.String   `tfsdk:"not_before"`
+	NotAfter  types.String   `tfsdk:"not_after"`
+	Managed   types.Bool     `tfsdk:"managed"`
+}
+
+// Metadata sets the data source type name for the CertificatesDataSource.
+func (d *CertificatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificates"
+}
+
+// Schema defines the structure and attributes of the CertificatesDataSource.
+func (d *CertificatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the TLS certificates of a cluster with their domains, issuer and validity, e.g. to monitor certificate expiry.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to list the certificates of.",
+				Required:            true,
+			},
+			"expires_within": schema.StringAttribute{
+				MarkdownDescription: "Only list the certificates that expire within this duration from now, e.g. `720h`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"certificates": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching certificates.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the certificate.",
+							Computed:            true,
+						},
+						"domains": schema.ListAttribute{
+							MarkdownDescription: "The domains the certificate is valid for.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"issuer": schema.StringAttribute{
+							MarkdownDescription: "The issuer of the certificate.",
+							Computed:            true,
+						},
+						"not_before": schema.StringAttribute{
+							MarkdownDescription: "The RFC 3339 timestamp from which the certificate is valid.",
+							Computed:            true,
+						},
+						"not_after": schema.StringAttribute{
+							MarkdownDescription: "The RFC 3339 timestamp at which the certificate expires.",
+							Computed:            true,
+						},
+						"managed": schema.BoolAttribute{
+							MarkdownDescription: "Whether the certificate is issued and renewed by Pomerium Zero, as opposed to uploaded.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the CertificatesDataSource.
+func (d *CertificatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the certificates of the cluster.
+func (d *CertificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CertificatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/certificates", apiBaseURL, d.organizationID, data.ClusterID.ValueString())
+
+	var certificates []Certificate
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &certificates); err != nil {
+		resp.Diagnostics.AddError("Error fetching certificates", err.Error())
+		return
+	}
+
+	var deadline time.Time
+	if !data.ExpiresWithin.IsNull() {
+		expiresWithin, _ := time.ParseDuration(data.ExpiresWithin.ValueString())
+		deadline = time.Now().Add(expiresWithin)
+	}
+
+	data.Certificates = []CertificateDataModel{}
+	for _, certificate := range certificates {
+		if !deadline.IsZero() {
+			notAfter, err := time.Parse(time.RFC3339, certificate.NotAfter)
+			if err != nil || notAfter.After(deadline) {
+				continue
+			}
+		}
+
+		domains := make([]types.String, 0, len(certificate.Domains))
+		for _, domain := range certificate.Domains {
+			domains = append(domains, types.StringValue(domain))
+		}
+
+		data.Certificates = append(data.Certificates, CertificateDataModel{
+			ID:        types.StringValue(certificate.ID),
+			Domains:   domains,
+			Issuer:    stringValueOrNull(certificate.Issuer),
+			NotBefore: stringValueOrNull(certificate.NotBefore),
+			NotAfter:  stringValueOrNull(certificate.NotAfter),
+			Managed:   types.BoolValue(certificate.Managed),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
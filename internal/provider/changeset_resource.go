@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ChangesetResource{}
+var _ resource.ResourceWithImportState = &ChangesetResource{}
+var _ resource.ResourceWithModifyPlan = &ChangesetResource{}
+
+// NewChangesetResource creates a new ChangesetResource.
+func NewChangesetResource() resource.Resource {
+	return &ChangesetResource{}
+}
+
+// ChangesetResource defines the resource implementation.
+// It applies the changesets pending on a cluster, which is needed to push changes live
+// when auto_apply_changesets is disabled in the cluster settings.
+type ChangesetResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ChangesetResourceModel describes the resource data model.
+type ChangesetResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ClusterID           types.String `tfsdk:"cluster_id"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	PendingChangesetIDs types.List   `tfsdk:"pending_changeset_ids"`
+	AppliedChangesetIDs types.List   `tfsdk:"applied_changeset_ids"`
+	AppliedRevision     types.String `tfsdk:"applied_revision"`
+}
+
+// Metadata sets the resource type name for the ChangesetResource.
+func (r *ChangesetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_changeset"
+}
+
+// Schema defines the structure and attributes of the ChangesetResource.
+func (r *ChangesetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies the changesets pending on a Pomerium Zero cluster. Use this resource to push route, policy and settings changes live " +
+			"as part of the Terraform run when `auto_apply_changesets` is disabled in the cluster settings. " +
+			"Pending changesets found while refreshing are applied on the next apply. Destroying this resource does not revert applied changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource. This corresponds to the cluster ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster whose pending changesets are applied.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause the pending changesets to be applied again when changed, " +
+					"e.g. the IDs of the routes and policies changed in the same run.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"pending_changeset_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the changesets that were pending on the cluster when it was last refreshed.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"applied_changeset_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the changesets that were applied when the pending changesets were last applied.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"applied_revision": schema.StringAttribute{
+				MarkdownDescription: "The revision of the cluster configuration after the pending changesets were last applied.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ChangesetResource.
+func (r *ChangesetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// ModifyPlan schedules an apply when changesets were found pending while refreshing.
+func (r *ChangesetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state ChangesetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(state.PendingChangesetIDs.Elements()) == 0 {
+		return
+	}
+
+	// Applying leaves no pending changesets behind, and results in a new revision
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_changeset_ids"), types.ListValueMust(types.StringType, nil))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("applied_changeset_ids"), types.ListUnknown(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("applied_revision"), types.StringUnknown())...)
+}
+
+// Create applies the pending changesets of the cluster.
+func (r *ChangesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ChangesetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision, appliedIDs, err := r.applyPendingChangesets(ctx, plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error applying changesets", err.Error())
+		return
+	}
+
+	appliedList, diags := types.ListValueFrom(ctx, types.StringType, appliedIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.AppliedChangesetIDs = appliedList
+
+	plan.ID = plan.ClusterID
+	plan.PendingChangesetIDs = types.ListValueMust(types.StringType, nil)
+	plan.AppliedRevision = types.StringValue(revision)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the list of changesets pending on the cluster.
+func (r *ChangesetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ChangesetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changesets, err := r.listPendingChangesets(ctx, state.ClusterID.ValueString())
+	if err != nil {
+		// The cluster was deleted, and its changesets with it
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading changesets", err.Error())
+		return
+	}
+
+	pendingIDs := make([]string, 0, len(changesets))
+	for _, changeset := range changesets {
+		pendingIDs = append(pendingIDs, changeset.ID)
+	}
+
+	pendingList, diags := types.ListValueFrom(ctx, types.StringType, pendingIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.PendingChangesetIDs = pendingList
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update applies the pending changesets of the cluster again.
+func (r *ChangesetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ChangesetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	revision, appliedIDs, err := r.applyPendingChangesets(ctx, plan.ClusterID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error applying changesets", err.Error())
+		return
+	}
+
+	appliedList, diags := types.ListValueFrom(ctx, types.StringType, appliedIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.AppliedChangesetIDs = appliedList
+
+	plan.PendingChangesetIDs = types.ListValueMust(types.StringType, nil)
+	plan.AppliedRevision = types.StringValue(revision)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. Applied changes are not reverted.
+func (r *ChangesetResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// ImportState imports the changeset resource of a cluster by the cluster ID.
+func (r *ChangesetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("triggers"), types.MapNull(types.StringType))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("applied_changeset_ids"), types.ListNull(types.StringType))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("applied_revision"), types.StringNull())...)
+}
+
+// listPendingChangesets fetches the changesets of a cluster that have not been applied yet.
+func (r *ChangesetResource) listPendingChangesets(ctx context.Context, clusterID string) ([]Changeset, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/changesets?status=pending", apiBaseURL, r.organizationID, clusterID)
+
+	var changesets []Changeset
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &changesets); err != nil {
+		return nil, err
+	}
+
+	return changesets, nil
+}
+
+// applyPendingChangesets applies all pending changesets of a cluster and returns the resulting revision,
+// and the IDs of the changesets that were pending before.
+func (r *ChangesetResource) applyPendingChangesets(ctx context.Context, clusterID string) (string, []string, error) {
+	changesets, err := r.listPendingChangesets(ctx, clusterID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	appliedIDs := make([]string, 0, len(changesets))
+	for _, changeset := range changesets {
+		log.Printf("[INFO] Applying changeset %s on cluster %s: %s", changeset.ID, clusterID, changeset.Description)
+		appliedIDs = append(appliedIDs, changeset.ID)
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/changesets/apply", apiBaseURL, r.organizationID, clusterID)
+
+	var result struct {
+		Revision string `json:"revision"`
+	}
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, nil, http.StatusOK, &result); err != nil {
+		return "", nil, err
+	}
+
+	return result.Revision, appliedIDs, nil
+}
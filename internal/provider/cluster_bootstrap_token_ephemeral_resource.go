@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ClusterBootstrapTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ClusterBootstrapTokenEphemeralResource{}
+
+// NewClusterBootstrapTokenEphemeralResource creates a new ClusterBootstrapTokenEphemeralResource.
+func NewClusterBootstrapTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ClusterBootstrapTokenEphemeralResource{}
+}
+
+// ClusterBootstrapTokenEphemeralResource defines the ephemeral resource implementation.
+// The token is minted when the ephemeral resource is opened and is never stored in the Terraform state.
+type ClusterBootstrapTokenEphemeralResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ClusterBootstrapTokenEphemeralResourceModel describes the ephemeral resource data model.
+type ClusterBootstrapTokenEphemeralResourceModel struct {
+	ClusterID types.String `tfsdk:"cluster_id"`
+	TTL       types.String `tfsdk:"ttl"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// Metadata sets the ephemeral resource type name for the ClusterBootstrapTokenEphemeralResource.
+func (r *ClusterBootstrapTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_bootstrap_token"
+}
+
+// Schema defines the structure and attributes of the ClusterBootstrapTokenEphemeralResource.
+func (r *ClusterBootstrapTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Mints a short-lived token that joins a freshly provisioned Pomerium instance to a Pomerium Zero cluster, " +
+			"e.g. to pass it into cloud-init. The token is not persisted in the Terraform state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to mint a bootstrap token for.",
+				Required:            true,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the token can be used to join the cluster, e.g. `1h`. When not set, the API default is used.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The bootstrap token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp after which the token can no longer be used.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ClusterBootstrapTokenEphemeralResource.
+func (r *ClusterBootstrapTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Open mints a new bootstrap token for the cluster.
+func (r *ClusterBootstrapTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClusterBootstrapTokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/bootstrap-tokens", apiBaseURL, r.organizationID, data.ClusterID.ValueString())
+	body := struct {
+		TTL string `json:"ttl,omitempty"`
+	}{
+		TTL: data.TTL.ValueString(),
+	}
+
+	var bootstrapToken BootstrapToken
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, body, http.StatusCreated, &bootstrapToken); err != nil {
+		resp.Diagnostics.AddError("Error creating cluster bootstrap token", err.Error())
+		return
+	}
+
+	data.Token = types.StringValue(bootstrapToken.Token)
+	data.ExpiresAt = types.StringValue(bootstrapToken.ExpiresAt)
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
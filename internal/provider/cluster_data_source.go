@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -29,16 +33,33 @@ type ClusterDataSource struct {
 
 // ClusterDataSourceModel describes the data source data model.
 type ClusterDataSourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	Name                types.String `tfsdk:"name"`
-	NamespaceID         types.String `tfsdk:"namespace_id"`
-	Domain              types.String `tfsdk:"domain"`
-	FQDN                types.String `tfsdk:"fqdn"`
-	AutoDetectIPAddress types.String `tfsdk:"auto_detect_ip_address"`
-	CreatedAt           types.String `tfsdk:"created_at"`
-	UpdatedAt           types.String `tfsdk:"updated_at"`
+	ID                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	NamespaceID           types.String `tfsdk:"namespace_id"`
+	Domain                types.String `tfsdk:"domain"`
+	FQDN                  types.String `tfsdk:"fqdn"`
+	AutoDetectIPAddress   types.String `tfsdk:"auto_detect_ip_address"`
+	CreatedAt             types.String `tfsdk:"created_at"`
+	UpdatedAt             types.String `tfsdk:"updated_at"`
+	Version               types.String `tfsdk:"version"`
+	ConnectivityState     types.String `tfsdk:"connectivity_state"`
+	PendingChangesetCount types.Int64  `tfsdk:"pending_changeset_count"`
+	WaitFor               types.Object `tfsdk:"wait_for"`
 }
 
+// clusterWaitForModel describes the data model of the wait_for block of the cluster data source.
+type clusterWaitForModel struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+}
+
+const (
+	// defaultClusterWaitTimeout is how long to wait for the cluster when the wait_for block sets no timeout.
+	defaultClusterWaitTimeout = 5 * time.Minute
+	// defaultClusterPollInterval is the delay between two lookups when the wait_for block sets no poll interval.
+	defaultClusterPollInterval = 10 * time.Second
+)
+
 // Metadata sets the data source type name for the ClusterDataSource.
 // It appends "_cluster" to the data source type name.
 func (d *ClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -95,6 +116,45 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: "Last update timestamp",
 				Computed:            true,
 			},
+			// Pomerium version running on the cluster, automatically computed
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Pomerium version running on the cluster",
+				Computed:            true,
+			},
+			// Connectivity state of the cluster, automatically computed
+			"connectivity_state": schema.StringAttribute{
+				MarkdownDescription: "Connectivity state of the cluster, e.g. `connected` or `disconnected`",
+				Computed:            true,
+			},
+			// Number of changesets not yet applied to the cluster, automatically computed
+			"pending_changeset_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of changesets not yet applied to the cluster",
+				Computed:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Retry settings for clusters that are being created, optional block
+			"wait_for": schema.SingleNestedBlock{
+				MarkdownDescription: "Retries the lookup until the cluster exists, instead of failing immediately. " +
+					"Useful in bootstrap pipelines, where the cluster is created moments before Terraform runs.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "How long to wait for the cluster, as a duration such as `5m`. Defaults to `5m`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringIsDuration(),
+						},
+					},
+					"poll_interval": schema.StringAttribute{
+						MarkdownDescription: "How long to wait between two lookups, as a duration such as `10s`. Defaults to `10s`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringIsDuration(),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -118,9 +178,7 @@ func (d *ClusterDataSource) Configure(ctx context.Context, req datasource.Config
 	}
 
 	// Set the ClusterDataSource fields with the provider's data
-	d.client = provider.client
-	d.token = provider.token
-	d.organizationID = provider.organizationID
+	d.client, d.token, d.organizationID = provider.apiCredentials()
 }
 
 // Read retrieves information about a Pomerium Zero cluster.
@@ -143,23 +201,65 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	// Fetch clusters from Pomerium Zero
-	clusters, err := d.GetClusters(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to fetch clusters", err.Error())
-		return
+	// Without a wait_for block, the cluster is looked up once
+	var timeout, pollInterval time.Duration
+	if !data.WaitFor.IsNull() && !data.WaitFor.IsUnknown() {
+		var waitFor clusterWaitForModel
+		resp.Diagnostics.Append(data.WaitFor.As(ctx, &waitFor, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var err error
+		if timeout, err = durationOrDefault(waitFor.Timeout, defaultClusterWaitTimeout); err != nil {
+			resp.Diagnostics.AddError("Invalid Timeout", "Unable to parse wait_for.timeout: "+err.Error())
+			return
+		}
+		if pollInterval, err = durationOrDefault(waitFor.PollInterval, defaultClusterPollInterval); err != nil {
+			resp.Diagnostics.AddError("Invalid Poll Interval", "Unable to parse wait_for.poll_interval: "+err.Error())
+			return
+		}
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
 	}
 
-	// Find the cluster with the matching name
 	var matchingCluster *Cluster
-	for _, cluster := range clusters {
-		if cluster.Name == data.Name.ValueString() {
-			matchingCluster = &cluster
+	for attempt := 0; ; attempt++ {
+		// Fetch clusters from Pomerium Zero, bypassing the cached list when polling
+		clusters, err := d.GetClusters(ctx, attempt > 0)
+		if err != nil {
+			resp.Diagnostics.Append(errorDiagnostics("Failed to fetch clusters", err.Error(), err)...)
+			return
+		}
+
+		// Find the cluster with the matching name
+		for _, cluster := range clusters {
+			if cluster.Name == data.Name.ValueString() {
+				matchingCluster = &cluster
+				break
+			}
+		}
+
+		if matchingCluster != nil || !time.Now().Add(pollInterval).Before(deadline) {
 			break
 		}
+
+		log.Printf("[DEBUG] Cluster %s not found yet, looking it up again in %s", data.Name.ValueString(), pollInterval)
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			resp.Diagnostics.Append(errorDiagnostics("Cluster not found", fmt.Sprintf("Stopped waiting for cluster %s: %s", data.Name.ValueString(), ctx.Err()), ctx.Err())...)
+			return
+		}
 	}
 
 	if matchingCluster == nil {
+		if timeout > 0 {
+			resp.Diagnostics.AddError("Cluster not found", fmt.Sprintf("No cluster found with name %s within %s", data.Name.ValueString(), timeout))
+			return
+		}
 		resp.Diagnostics.AddError("Cluster not found", fmt.Sprintf("No cluster found with name: %s", data.Name.ValueString()))
 		return
 	}
@@ -172,6 +272,9 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	data.AutoDetectIPAddress = types.StringValue(matchingCluster.AutoDetectIPAddress)
 	data.CreatedAt = types.StringValue(matchingCluster.CreatedAt)
 	data.UpdatedAt = types.StringValue(matchingCluster.UpdatedAt)
+	data.Version = stringValueOrNull(matchingCluster.Version)
+	data.ConnectivityState = stringValueOrNull(matchingCluster.ConnectivityState)
+	data.PendingChangesetCount = types.Int64Value(matchingCluster.PendingChangesetCount)
 
 	tflog.Trace(ctx, "read a cluster data source")
 
@@ -179,9 +282,10 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// GetClusters fetches all clusters from Pomerium Zero.
-func (d *ClusterDataSource) GetClusters(ctx context.Context) ([]Cluster, error) {
-	url := fmt.Sprintf("https://console.pomerium.app/api/v0/organizations/%s/clusters", d.organizationID)
+// GetClusters fetches all clusters from Pomerium Zero. When bypassCache is set, the list is fetched
+// from the API even when it was already listed during this operation.
+func (d *ClusterDataSource) GetClusters(ctx context.Context, bypassCache bool) ([]Cluster, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters", apiBaseURL, d.organizationID)
 
 	// Create a new HTTP request
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -192,6 +296,9 @@ func (d *ClusterDataSource) GetClusters(ctx context.Context) ([]Cluster, error)
 	// Set the request headers
 	req.Header.Set("Authorization", "Bearer "+d.token)
 	req.Header.Set("Content-Type", "application/json")
+	if bypassCache {
+		req.Header.Set("Cache-Control", "no-cache")
+	}
 
 	resp, err := d.client.Do(req)
 	if err != nil {
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -107,9 +108,7 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.client = provider.client
-	r.token = provider.token
-	r.organizationID = provider.organizationID
+	r.client, r.token, r.organizationID = provider.apiCredentials()
 }
 
 // Create creates a new cluster in Pomerium Zero.
@@ -147,7 +146,7 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Fetch the cluster from Pomerium Zero
 	cluster, err := r.getCluster(ctx, state.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "cluster not found") {
+		if errors.Is(err, errNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -324,8 +323,8 @@ func (r *ClusterResource) getCluster(ctx context.Context, id string) (*Cluster,
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("cluster not found")
+	if isNotFoundStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("GET %s: %w", url, errNotFound)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// clusterSettingsKeys returns the keys of the cluster settings that the resource models with typed
+// attributes, which extra_settings must not set.
+func clusterSettingsKeys() map[string]bool {
+	keys := make(map[string]bool)
+	settingsType := reflect.TypeOf(ClusterSettings{})
+	for i := 0; i < settingsType.NumField(); i++ {
+		name, _, _ := strings.Cut(settingsType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// decodeExtraSettings decodes the extra_settings attribute, which is null or a JSON object.
+func decodeExtraSettings(value types.String) (map[string]json.RawMessage, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	var extra map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value.ValueString()), &extra); err != nil || extra == nil {
+		return nil, fmt.Errorf("extra_settings must be a JSON object, e.g. jsonencode({ newSetting = true })")
+	}
+	return extra, nil
+}
+
+// mergeExtraSettings adds the extra settings to the JSON object of a request body.
+func mergeExtraSettings(body []byte, extra map[string]json.RawMessage) ([]byte, error) {
+	if len(extra) == 0 {
+		return body, nil
+	}
+
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(body, &merged); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// readExtraSettings returns the values that the API returned for the keys of the prior extra_settings,
+// dropping the keys it did not return. The prior value is kept when it is equal to them as JSON, so that
+// formatting differences do not show as changes.
+func readExtraSettings(prior types.String, settings map[string]json.RawMessage) types.String {
+	extra, err := decodeExtraSettings(prior)
+	if err != nil || extra == nil {
+		return prior
+	}
+
+	current := make(map[string]json.RawMessage, len(extra))
+	for key := range extra {
+		if value, ok := settings[key]; ok {
+			current[key] = value
+		}
+	}
+
+	encoded, err := json.Marshal(current)
+	if err != nil {
+		return prior
+	}
+
+	var priorValue, currentValue interface{}
+	if json.Unmarshal([]byte(prior.ValueString()), &priorValue) == nil && json.Unmarshal(encoded, &currentValue) == nil &&
+		reflect.DeepEqual(priorValue, currentValue) {
+		return prior
+	}
+	return types.StringValue(string(encoded))
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = extraSettingsValidator{}
+
+// extraSettingsValidator validates that extra_settings is a JSON object that only sets keys without a typed attribute.
+type extraSettingsValidator struct{}
+
+// Description returns a plain text description of the validator's behavior.
+func (v extraSettingsValidator) Description(_ context.Context) string {
+	return "value must be a JSON object whose keys are not managed by other attributes"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v extraSettingsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v extraSettingsValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	extra, err := decodeExtraSettings(req.ConfigValue)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Extra Settings", err.Error())
+		return
+	}
+
+	modeled := clusterSettingsKeys()
+	var conflicts []string
+	for key := range extra {
+		if modeled[key] {
+			conflicts = append(conflicts, key)
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Extra Settings",
+			fmt.Sprintf("extra_settings cannot set %s, which the resource manages with typed attributes. Use those attributes instead.",
+				strings.Join(conflicts, ", ")),
+		)
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jwtEndpoints returns the issuer of the JWTs that a cluster whose domain is clusterDomain passes to
+// upstream applications, and the URL of the keys to verify them with. The issuer is the host of the
+// authenticate service when a custom one is configured, and the cluster domain otherwise.
+func jwtEndpoints(clusterDomain, authenticateServiceURL string) (issuer, jwksURL string) {
+	issuer = clusterDomain
+	if u, err := url.Parse(authenticateServiceURL); err == nil && u.Hostname() != "" {
+		issuer = u.Hostname()
+	}
+	return issuer, fmt.Sprintf("https://%s/.well-known/pomerium/jwks.json", clusterDomain)
+}
+
+// knownClusterDomain returns the domain of the cluster when it is known without calling the API,
+// i.e. from cluster_domain or from the jwks_url set earlier, or an empty string otherwise.
+func knownClusterDomain(model *ClusterSettingsResourceModel) string {
+	if !model.ClusterDomain.IsNull() && !model.ClusterDomain.IsUnknown() {
+		return model.ClusterDomain.ValueString()
+	}
+	if !model.JWKSURL.IsNull() && !model.JWKSURL.IsUnknown() {
+		if u, err := url.Parse(model.JWKSURL.ValueString()); err == nil {
+			return u.Hostname()
+		}
+	}
+	return ""
+}
+
+// setJWTEndpoints sets jwt_issuer and jwks_url of the model, looking up the domain of the cluster
+// when it is not known yet.
+func (r *ClusterSettingsResource) setJWTEndpoints(ctx context.Context, model *ClusterSettingsResourceModel) error {
+	domain := knownClusterDomain(model)
+	if domain == "" {
+		clusters := &ClusterResource{client: r.client, token: r.token, organizationID: r.organizationID}
+		cluster, err := clusters.getCluster(ctx, model.ID.ValueString())
+		if err != nil {
+			return fmt.Errorf("unable to look up the domain of cluster %s: %w", model.ID.ValueString(), err)
+		}
+		domain = cluster.FQDN
+	}
+
+	issuer, jwksURL := jwtEndpoints(domain, model.AuthenticateServiceUrl.ValueString())
+	model.JWTIssuer = types.StringValue(issuer)
+	model.JWKSURL = types.StringValue(jwksURL)
+	return nil
+}
+
+// planJWTEndpoints sets jwt_issuer and jwks_url of the plan when they can be derived without calling
+// the API, i.e. from cluster_domain or from the state of the same cluster. They are left unknown otherwise.
+func planJWTEndpoints(plan, state *ClusterSettingsResourceModel) {
+	if plan.AuthenticateServiceUrl.IsUnknown() || plan.ClusterDomain.IsUnknown() || plan.ID.IsUnknown() {
+		return
+	}
+
+	domain := ""
+	if !plan.ClusterDomain.IsNull() {
+		domain = plan.ClusterDomain.ValueString()
+	} else if state != nil && state.ID.Equal(plan.ID) {
+		domain = knownClusterDomain(state)
+	}
+	if domain == "" {
+		return
+	}
+
+	issuer, jwksURL := jwtEndpoints(domain, plan.AuthenticateServiceUrl.ValueString())
+	plan.JWTIssuer = types.StringValue(issuer)
+	plan.JWKSURL = types.StringValue(jwksURL)
+}
@@ -4,29 +4,58 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	resource_schema_planmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	resource_schema_stringplanmodifier "github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterSettingsResource{}
 var _ resource.ResourceWithImportState = &ClusterSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterSettingsResource{}
+var _ resource.ResourceWithConfigValidators = &ClusterSettingsResource{}
 
 // NewClusterSettingsResource creates a new ClusterSettingsResource.
 func NewClusterSettingsResource() resource.Resource {
 	return &ClusterSettingsResource{}
 }
 
+// proxyLogLevels are the log levels accepted by the API for the proxy component.
+var proxyLogLevels = []string{"trace", "debug", "info", "warn", "error", "critical", "off"}
+
+// dnsLookupFamilies are the DNS lookup families accepted by the API.
+var dnsLookupFamilies = []string{"AUTO", "V4_ONLY", "V6_ONLY", "V4_PREFERRED", "ALL"}
+
+// hexColorPattern matches the #RRGGBB colors accepted by the branding options.
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Default deadlines of the cluster settings operations, which can be overridden with the timeouts block.
+// Writes can trigger a synchronous changeset apply, so they get more time than reads.
+const (
+	clusterSettingsCreateTimeout = 5 * time.Minute
+	clusterSettingsReadTimeout   = 1 * time.Minute
+	clusterSettingsUpdateTimeout = 5 * time.Minute
+	clusterSettingsDeleteTimeout = 1 * time.Minute
+)
+
+// errClusterSettingsChanged is returned when an update is rejected because the settings
+// were modified since they were last read.
+var errClusterSettingsChanged = errors.New("cluster settings were changed outside of Terraform")
+
 // ClusterSettingsResource defines the resource implementation.
 type ClusterSettingsResource struct {
 	client         *http.Client
@@ -36,27 +65,44 @@ type ClusterSettingsResource struct {
 
 // ClusterSettingsResourceModel describes the resource data model.
 type ClusterSettingsResourceModel struct {
-	ID                           types.String  `tfsdk:"id"`
-	Address                      types.String  `tfsdk:"address"`
-	AuthenticateServiceUrl       types.String  `tfsdk:"authenticate_service_url"`
-	AutoApplyChangesets          types.Bool    `tfsdk:"auto_apply_changesets"`
-	CookieExpire                 types.String  `tfsdk:"cookie_expire"`
-	CookieHttpOnly               types.Bool    `tfsdk:"cookie_http_only"`
-	CookieName                   types.String  `tfsdk:"cookie_name"`
-	DefaultUpstreamTimeout       types.String  `tfsdk:"default_upstream_timeout"`
-	DNSLookupFamily              types.String  `tfsdk:"dns_lookup_family"`
-	IdentityProvider             types.String  `tfsdk:"identity_provider"`
-	IdentityProviderClientId     types.String  `tfsdk:"identity_provider_client_id"`
-	IdentityProviderClientSecret types.String  `tfsdk:"identity_provider_client_secret"`
-	IdentityProviderUrl          types.String  `tfsdk:"identity_provider_url"`
-	LogLevel                     types.String  `tfsdk:"log_level"`
-	PassIdentityHeaders          types.Bool    `tfsdk:"pass_identity_headers"`
-	ProxyLogLevel                types.String  `tfsdk:"proxy_log_level"`
-	SkipXffAppend                types.Bool    `tfsdk:"skip_xff_append"`
-	TimeoutIdle                  types.String  `tfsdk:"timeout_idle"`
-	TimeoutRead                  types.String  `tfsdk:"timeout_read"`
-	TimeoutWrite                 types.String  `tfsdk:"timeout_write"`
-	TracingSampleRate            types.Float64 `tfsdk:"tracing_sample_rate"`
+	ID                                  types.String    `tfsdk:"id"`
+	Address                             types.String    `tfsdk:"address"`
+	AuthenticateServiceUrl              types.String    `tfsdk:"authenticate_service_url"`
+	ClusterDomain                       types.String    `tfsdk:"cluster_domain"`
+	JWTIssuer                           types.String    `tfsdk:"jwt_issuer"`
+	JWKSURL                             types.String    `tfsdk:"jwks_url"`
+	AutoApplyChangesets                 types.Bool      `tfsdk:"auto_apply_changesets"`
+	CookieExpire                        durationValue   `tfsdk:"cookie_expire"`
+	CookieHttpOnly                      types.Bool      `tfsdk:"cookie_http_only"`
+	CookieName                          types.String    `tfsdk:"cookie_name"`
+	DefaultUpstreamTimeout              durationValue   `tfsdk:"default_upstream_timeout"`
+	DNSLookupFamily                     types.String    `tfsdk:"dns_lookup_family"`
+	IdentityProvider                    types.String    `tfsdk:"identity_provider"`
+	IdentityProviderClientId            types.String    `tfsdk:"identity_provider_client_id"`
+	IdentityProviderClientSecret        types.String    `tfsdk:"identity_provider_client_secret"`
+	IdentityProviderClientSecretVersion types.String    `tfsdk:"identity_provider_client_secret_version"`
+	IdentityProviderUrl                 types.String    `tfsdk:"identity_provider_url"`
+	LogLevel                            types.String    `tfsdk:"log_level"`
+	LogFormat                           types.String    `tfsdk:"log_format"`
+	AccessLogEnabled                    types.Bool      `tfsdk:"access_log_enabled"`
+	PassIdentityHeaders                 types.Bool      `tfsdk:"pass_identity_headers"`
+	ProxyLogLevel                       types.String    `tfsdk:"proxy_log_level"`
+	SkipXffAppend                       types.Bool      `tfsdk:"skip_xff_append"`
+	TimeoutIdle                         durationValue   `tfsdk:"timeout_idle"`
+	TimeoutRead                         durationValue   `tfsdk:"timeout_read"`
+	TimeoutWrite                        durationValue   `tfsdk:"timeout_write"`
+	TracingSampleRate                   sampleRateValue `tfsdk:"tracing_sample_rate"`
+	PrimaryColor                        types.String    `tfsdk:"primary_color"`
+	SecondaryColor                      types.String    `tfsdk:"secondary_color"`
+	DarkmodePrimaryColor                types.String    `tfsdk:"darkmode_primary_color"`
+	DarkmodeSecondaryColor              types.String    `tfsdk:"darkmode_secondary_color"`
+	LogoUrl                             types.String    `tfsdk:"logo_url"`
+	FaviconUrl                          types.String    `tfsdk:"favicon_url"`
+	ErrorMessageFirstParagraph          types.String    `tfsdk:"error_message_first_paragraph"`
+	ErrorPageSupportUrl                 types.String    `tfsdk:"error_page_support_url"`
+	ExtraSettings                       types.String    `tfsdk:"extra_settings"`
+	WaitForDeployment                   types.Bool      `tfsdk:"wait_for_deployment"`
+	Timeouts                            types.Object    `tfsdk:"timeouts"`
 }
 
 // Metadata sets the resource type name for the ClusterSettingsResource.
@@ -92,8 +138,12 @@ func (r *ClusterSettingsResource) Schema(_ context.Context, _ resource.SchemaReq
 			},
 			// CookieExpire sets the lifetime of authentication cookies
 			"cookie_expire": resource_schema.StringAttribute{
+				CustomType:          durationType{},
 				Optional:            true,
 				MarkdownDescription: "The expiration time for cookies.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
 			},
 			// CookieHttpOnly restricts cookie access to HTTP(S) requests only
 			"cookie_http_only": resource_schema.BoolAttribute{
@@ -107,13 +157,20 @@ func (r *ClusterSettingsResource) Schema(_ context.Context, _ resource.SchemaReq
 			},
 			// DefaultUpstreamTimeout sets the default timeout for upstream requests
 			"default_upstream_timeout": resource_schema.StringAttribute{
+				CustomType:          durationType{},
 				Optional:            true,
 				MarkdownDescription: "The default timeout for upstream requests.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
 			},
 			// DNSLookupFamily specifies the IP address family for DNS lookups
 			"dns_lookup_family": resource_schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The DNS lookup family to use (e.g., 'v4', 'v6').",
+				MarkdownDescription: "The DNS lookup family to use. Must be one of `AUTO`, `V4_ONLY`, `V6_ONLY`, `V4_PREFERRED` or `ALL`.",
+				Validators: []validator.String{
+					stringOneOf(dnsLookupFamilies...),
+				},
 			},
 			// IdentityProvider specifies the authentication provider
 			"identity_provider": resource_schema.StringAttribute{
@@ -127,99 +184,287 @@ func (r *ClusterSettingsResource) Schema(_ context.Context, _ resource.SchemaReq
 			},
 			// IdentityProviderClientSecret is the client secret for the identity provider
 			"identity_provider_client_secret": resource_schema.StringAttribute{
-				Optional:            true,
-				Sensitive:           true,
-				MarkdownDescription: "The client secret for the identity provider (required if using custom IDP).",
+				Optional:  true,
+				Sensitive: true,
+				MarkdownDescription: "The client secret for the identity provider (required if using custom IDP). " +
+					"The secret is stored in the Terraform state, so the state must be protected like the secret itself.",
+			},
+			// IdentityProviderClientSecretVersion forces the client secret to be sent again when changed
+			"identity_provider_client_secret_version": resource_schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "An arbitrary value, such as a number or a date, to change when the client secret is rotated. " +
+					"The API never returns the client secret, so Terraform cannot detect that it was changed outside of Terraform; " +
+					"changing this value updates the settings, which sends the configured client secret to Pomerium Zero again.",
 			},
 			// IdentityProviderUrl is the URL of the identity provider
 			"identity_provider_url": resource_schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The URL of the identity provider (required if using custom IDP).",
+				Validators: []validator.String{
+					stringIsURL("https"),
+				},
 			},
 			// AuthenticateServiceUrl is the endpoint for the authentication service
 			"authenticate_service_url": resource_schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The URL of the authentication service (required if using custom IDP).",
+				Validators: []validator.String{
+					stringIsURL("https"),
+				},
+			},
+			// ClusterDomain is only used to validate authenticate_service_url at plan time
+			"cluster_domain": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The fully qualified domain of the cluster, e.g. `pomeriumzero_cluster.default.fqdn`. Only used at plan time to check that `authenticate_service_url` is served from the cluster's domain, and to derive `jwt_issuer` and `jwks_url`. When not set, the domain is looked up from the cluster.",
+			},
+			// JWTIssuer and JWKSURL let upstream applications verify the JWTs of the cluster
+			"jwt_issuer": resource_schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The issuer (`iss` claim) of the JWTs the cluster passes to upstream applications: " +
+					"the host of `authenticate_service_url` when set, and the cluster domain otherwise.",
+			},
+			"jwks_url": resource_schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL of the JSON Web Key Set that upstream applications verify the JWTs of the cluster with.",
 			},
 			// LogLevel sets the logging verbosity for the Pomerium Zero cluster
 			"log_level": resource_schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The log level for the Pomerium Zero cluster.",
 			},
+			// LogFormat sets the output format of the Pomerium Zero cluster logs
+			"log_format": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The output format for the Pomerium Zero cluster logs. Must be one of `json` or `console`.",
+				Validators: []validator.String{
+					stringOneOf("json", "console"),
+				},
+			},
+			// AccessLogEnabled toggles access logging for proxied requests
+			"access_log_enabled": resource_schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether access logs are emitted for proxied requests.",
+			},
 			// PassIdentityHeaders determines if identity information should be passed to upstream services
 			"pass_identity_headers": resource_schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "Whether to pass identity headers to upstream services.",
+				MarkdownDescription: "Whether to pass identity headers to upstream services. When not set, the cluster default is used.",
 			},
 			// ProxyLogLevel sets the logging verbosity for the proxy component
+			// The API does not accept clearing it once set, so removing it from the configuration retains the current value
 			"proxy_log_level": resource_schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The log level for the proxy component.",
+				Computed:            true,
+				MarkdownDescription: "The log level for the proxy component. Must be one of `trace`, `debug`, `info`, `warn`, `error`, `critical` or `off`. Once set, removing it from the configuration keeps the current value, as the API does not support clearing it.",
+				Validators: []validator.String{
+					stringOneOf(proxyLogLevels...),
+				},
+				PlanModifiers: []resource_schema_planmodifier.String{
+					retainStateWhenUnset(),
+				},
 			},
 			// SkipXffAppend determines if X-Forwarded-For headers should be appended
 			"skip_xff_append": resource_schema.BoolAttribute{
 				Optional:            true,
-				MarkdownDescription: "Whether to skip appending X-Forwarded-For headers.",
+				MarkdownDescription: "Whether to skip appending X-Forwarded-For headers. When not set, the cluster default is used.",
 			},
 			// TimeoutIdle sets the idle timeout for connections
 			"timeout_idle": resource_schema.StringAttribute{
+				CustomType:          durationType{},
 				Optional:            true,
 				MarkdownDescription: "The idle timeout for connections.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
 			},
 			// TimeoutRead sets the read timeout for connections
 			"timeout_read": resource_schema.StringAttribute{
+				CustomType:          durationType{},
 				Optional:            true,
 				MarkdownDescription: "The read timeout for connections.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
 			},
 			// TimeoutWrite sets the write timeout for connections
 			"timeout_write": resource_schema.StringAttribute{
+				CustomType:          durationType{},
 				Optional:            true,
 				MarkdownDescription: "The write timeout for connections.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
 			},
 			// TracingSampleRate sets the sampling rate for tracing
 			"tracing_sample_rate": resource_schema.Float64Attribute{
+				CustomType:          sampleRateType{},
 				Optional:            true,
 				MarkdownDescription: "The sampling rate for tracing.",
 			},
+			// Branding options customize the look and feel of the authentication pages
+			"primary_color": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The primary color of the authentication pages, as a `#RRGGBB` hex color.",
+				Validators: []validator.String{
+					stringMatches(hexColorPattern, "value must be a hex color such as \"#6f43e7\""),
+				},
+			},
+			"secondary_color": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The secondary color of the authentication pages, as a `#RRGGBB` hex color.",
+				Validators: []validator.String{
+					stringMatches(hexColorPattern, "value must be a hex color such as \"#6f43e7\""),
+				},
+			},
+			"darkmode_primary_color": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The primary color of the authentication pages in dark mode, as a `#RRGGBB` hex color.",
+				Validators: []validator.String{
+					stringMatches(hexColorPattern, "value must be a hex color such as \"#6f43e7\""),
+				},
+			},
+			"darkmode_secondary_color": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The secondary color of the authentication pages in dark mode, as a `#RRGGBB` hex color.",
+				Validators: []validator.String{
+					stringMatches(hexColorPattern, "value must be a hex color such as \"#6f43e7\""),
+				},
+			},
+			"logo_url": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of the logo displayed on the authentication pages.",
+				Validators: []validator.String{
+					stringIsURL("http", "https"),
+				},
+			},
+			"favicon_url": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of the favicon used by the authentication pages.",
+				Validators: []validator.String{
+					stringIsURL("http", "https"),
+				},
+			},
+			// Error page options customize what end users see when access is denied
+			"error_message_first_paragraph": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Markdown shown as the first paragraph of the error page displayed to users who are denied access, e.g. to explain how to request access. Raw HTML is not allowed and links must use the `http`, `https` or `mailto` scheme.",
+				Validators: []validator.String{
+					stringIsMarkdown(),
+				},
+			},
+			"error_page_support_url": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The URL of the support page linked from the error page displayed to users who are denied access.",
+				Validators: []validator.String{
+					stringIsURL("http", "https"),
+				},
+			},
+			// ExtraSettings passes settings that have no typed attribute yet through to the API
+			"extra_settings": resource_schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Settings that have no attribute in this resource yet, as a JSON object, e.g. `jsonencode({ newSetting = true })`. " +
+					"The keys are the names of the settings in the Pomerium Zero API, and are merged into the settings sent on create and update. " +
+					"Only the configured keys are read back, so removing a key stops managing the setting without resetting it. " +
+					"Keys of settings that have an attribute cannot be set.",
+				Validators: []validator.String{
+					extraSettingsValidator{},
+				},
+			},
+			// WaitForDeployment makes changes wait until they are deployed to the cluster
+			"wait_for_deployment": waitForDeploymentAttribute("cluster settings"),
+		},
+		Blocks: map[string]resource_schema.Block{
+			// Timeouts overrides the default deadline of each operation
+			"timeouts": timeoutsBlock(),
 		},
 	}
 }
 
-// ValidateConfig checks the configuration for the ClusterSettingsResource
-// It ensures that the identity provider fields are set correctly, or not set at all
-func (r *ClusterSettingsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data ClusterSettingsResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+// ConfigValidators returns the validations spanning several attributes of the ClusterSettingsResource.
+// A custom identity provider needs all of its fields, while none of them are set for Hosted Authenticate.
+func (r *ClusterSettingsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		requiredTogether(
+			"identity_provider",
+			"identity_provider_client_id",
+			"identity_provider_client_secret",
+			"identity_provider_url",
+			"authenticate_service_url",
+		),
+	}
+}
 
+// ModifyPlan warns when authenticate_service_url points outside of the cluster's domain.
+// Hosted DNS and certificates are only provisioned for the cluster's own domain, so such
+// a URL usually results in a broken login flow.
+func (r *ClusterSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ClusterSettingsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Normalize ProxyLogLevel
-	if !data.ProxyLogLevel.IsNull() && data.ProxyLogLevel.ValueString() == "" {
-		data.ProxyLogLevel = types.StringNull()
+	// Derive the JWT outputs at plan time when possible, instead of showing them as known after apply
+	var state *ClusterSettingsResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &ClusterSettingsResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
+	planJWTEndpoints(&plan, state)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("jwt_issuer"), plan.JWTIssuer)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("jwks_url"), plan.JWKSURL)...)
 
-	// Check if any of the identity provider fields are set
-	idpFieldsSet := !data.IdentityProvider.IsNull() ||
-		!data.IdentityProviderClientId.IsNull() ||
-		!data.IdentityProviderClientSecret.IsNull() ||
-		!data.IdentityProviderUrl.IsNull() ||
-		!data.AuthenticateServiceUrl.IsNull()
+	if plan.AuthenticateServiceUrl.IsNull() || plan.AuthenticateServiceUrl.IsUnknown() {
+		return
+	}
 
-	// If any field is set, all must be set
-	if idpFieldsSet {
-		if data.IdentityProvider.IsNull() ||
-			data.IdentityProviderClientId.IsNull() ||
-			data.IdentityProviderClientSecret.IsNull() ||
-			data.IdentityProviderUrl.IsNull() ||
-			data.AuthenticateServiceUrl.IsNull() {
-			resp.Diagnostics.AddError(
-				"Invalid Identity Provider Configuration",
-				"When configuring a custom identity provider, all related fields (identity_provider, "+
-					"identity_provider_client_id, identity_provider_client_secret, identity_provider_url, authenticate_service_url) must be provided.",
-			)
+	parsed, err := url.Parse(plan.AuthenticateServiceUrl.ValueString())
+	if err != nil || parsed.Hostname() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("authenticate_service_url"),
+			"Invalid Authenticate Service URL",
+			fmt.Sprintf("authenticate_service_url must be an absolute URL, got: %q", plan.AuthenticateServiceUrl.ValueString()),
+		)
+		return
+	}
+
+	// Prefer the supplied domain, otherwise look it up from the cluster
+	domain := ""
+	if plan.ClusterDomain.IsUnknown() {
+		return
+	} else if !plan.ClusterDomain.IsNull() {
+		domain = plan.ClusterDomain.ValueString()
+	} else if !plan.ID.IsNull() && !plan.ID.IsUnknown() && r.client != nil {
+		clusters := &ClusterResource{client: r.client, token: r.token, organizationID: r.organizationID}
+		cluster, err := clusters.getCluster(ctx, plan.ID.ValueString())
+		if err != nil {
+			log.Printf("[DEBUG] Unable to fetch cluster %s to validate authenticate_service_url: %s", plan.ID.ValueString(), err)
+			return
 		}
+		domain = cluster.FQDN
+	}
+
+	if domain == "" {
+		return
+	}
+
+	if !hostWithinDomain(parsed.Hostname(), domain) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("authenticate_service_url"),
+			"Authenticate Service URL Outside Cluster Domain",
+			fmt.Sprintf("The host %q of authenticate_service_url is not part of the cluster domain %q. "+
+				"Pomerium Zero only manages DNS records and certificates for the cluster domain, "+
+				"so authentication will fail unless DNS and certificates for this host are managed elsewhere.",
+				parsed.Hostname(), domain),
+		)
 	}
 }
 
@@ -242,9 +487,7 @@ func (r *ClusterSettingsResource) Configure(ctx context.Context, req resource.Co
 	}
 
 	// Set the resource's client, token, and organizationID from the provider data
-	r.client = provider.client
-	r.token = provider.token
-	r.organizationID = provider.organizationID
+	r.client, r.token, r.organizationID = provider.apiCredentials()
 }
 
 // Create handles the creation of a new ClusterSettingsResource
@@ -259,15 +502,14 @@ func (r *ClusterSettingsResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	// Explicitly call ValidateConfig
-	validateResp := &resource.ValidateConfigResponse{
-		Diagnostics: resp.Diagnostics,
-	}
-	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: req.Config}, validateResp)
-	resp.Diagnostics = validateResp.Diagnostics
+	// Bound the operation by the create timeout
+	timeout, diags := operationTimeout(ctx, plan.Timeouts, "create", clusterSettingsCreateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, timeout)
+	defer cancel()
 
 	log.Printf("[DEBUG] Creating cluster settings for cluster: %s", plan.ID.ValueString())
 
@@ -278,16 +520,24 @@ func (r *ClusterSettingsResource) Create(ctx context.Context, req resource.Creat
 	settings, err := r.createClusterSettings(ctx, settingsReq)
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error creating cluster settings", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error creating cluster settings", err, req.Plan)...)
 		return
 	}
 
 	// Update the plan with the ID returned from the API
 	plan.ID = types.StringValue(settings.ID)
+	if err := r.setJWTEndpoints(ctx, &plan); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error creating cluster settings", err.Error(), err)...)
+		return
+	}
 
-	// Set the updated plan as the new state
+	// Set the updated plan as the new state, and keep the version for optimistic locking
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setObjectVersion(ctx, resp.Private, objectVersion{ETag: settings.ETag})...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, isCluster(plan.ID.ValueString()))...)
 }
 
 // Read retrieves the current state of the ClusterSettingsResource
@@ -300,6 +550,15 @@ func (r *ClusterSettingsResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	// Bound the operation by the read timeout
+	timeout, diags := operationTimeout(ctx, state.Timeouts, "read", clusterSettingsReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, timeout)
+	defer cancel()
+
 	// Get the ID of the cluster settings from the state
 	id := state.ID.ValueString()
 	log.Printf("[DEBUG] Reading cluster settings for cluster: %s", id)
@@ -308,89 +567,31 @@ func (r *ClusterSettingsResource) Read(ctx context.Context, req resource.ReadReq
 	apiSettings, err := r.getClusterSettings(ctx, id)
 	if err != nil {
 		// If the settings are not found, remove the resource from the state
-		if strings.Contains(err.Error(), "settings not found") {
+		if errors.Is(err, errNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
 		// If there's any other error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error reading cluster settings", err.Error())
+		resp.Diagnostics.Append(errorDiagnostics("Error reading cluster settings", err.Error(), err)...)
 		return
 	}
 
 	// Update the state with the fetched settings
-	// For non-nullable fields, we can directly set the values
-	state.Address = types.StringValue(apiSettings.Address)
-	state.AutoApplyChangesets = types.BoolValue(apiSettings.AutoApplyChangesets)
-	state.CookieExpire = types.StringValue(apiSettings.CookieExpire)
-	state.CookieHttpOnly = types.BoolValue(apiSettings.CookieHttpOnly)
-	state.CookieName = types.StringValue(apiSettings.CookieName)
-	state.DefaultUpstreamTimeout = types.StringValue(apiSettings.DefaultUpstreamTimeout)
-	state.DNSLookupFamily = types.StringValue(apiSettings.DNSLookupFamily)
-	state.LogLevel = types.StringValue(apiSettings.LogLevel)
-	state.PassIdentityHeaders = types.BoolValue(apiSettings.PassIdentityHeaders)
-	state.SkipXffAppend = types.BoolValue(apiSettings.SkipXffAppend)
-	state.TimeoutIdle = types.StringValue(apiSettings.TimeoutIdle)
-	state.TimeoutRead = types.StringValue(apiSettings.TimeoutRead)
-	state.TimeoutWrite = types.StringValue(apiSettings.TimeoutWrite)
-	state.TracingSampleRate = types.Float64Value(apiSettings.TracingSampleRate)
-
-	// Handle potentially null values
-	// For fields that can be null, we need to check if they're empty and set them to null if so
-	// This ensures that Terraform correctly detects when a field is unset, rather than set to an empty string
-
-	// Handle potentially null values
-
-	// AuthenticateServiceUrl
-	if apiSettings.AuthenticateServiceUrl != "" {
-		state.AuthenticateServiceUrl = types.StringValue(apiSettings.AuthenticateServiceUrl)
-	} else {
-		state.AuthenticateServiceUrl = types.StringNull()
-	}
-
-	// IdentityProvider
-	if apiSettings.IdentityProvider != "" {
-		state.IdentityProvider = types.StringValue(apiSettings.IdentityProvider)
-	} else {
-		state.IdentityProvider = types.StringNull()
-	}
-
-	// IdentityProviderClientId
-	if apiSettings.IdentityProviderClientId != "" {
-		state.IdentityProviderClientId = types.StringValue(apiSettings.IdentityProviderClientId)
-	} else {
-		state.IdentityProviderClientId = types.StringNull()
-	}
-
-	// IdentityProviderClientSecret
-	if apiSettings.IdentityProviderClientSecret != nil {
-		state.IdentityProviderClientSecret = types.StringValue(*apiSettings.IdentityProviderClientSecret)
-	} else {
-		state.IdentityProviderClientSecret = types.StringNull()
-	}
-
-	// IdentityProviderUrl
-	if apiSettings.IdentityProviderUrl != "" {
-		state.IdentityProviderUrl = types.StringValue(apiSettings.IdentityProviderUrl)
-	} else {
-		state.IdentityProviderUrl = types.StringNull()
-	}
-
-	// Special handling for ProxyLogLevel
-	// The API may return null for this field, but doesn't accept null as a value when updating
-	// If it's an empty string from the API, we set it to null in the Terraform state
-	if apiSettings.ProxyLogLevel == "" {
-		state.ProxyLogLevel = types.StringNull()
-	} else {
-		state.ProxyLogLevel = types.StringValue(apiSettings.ProxyLogLevel)
-	}
+	updateClusterSettingsResourceModel(&state, apiSettings)
+	state.ExtraSettings = readExtraSettings(state.ExtraSettings, apiSettings.Raw)
 
 	// Ensure the ID in the state matches the one from the API
 	state.ID = types.StringValue(id)
+	if err := r.setJWTEndpoints(ctx, &state); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error reading cluster settings", err.Error(), err)...)
+		return
+	}
 
 	// Set the updated state
 	diags = resp.State.Set(ctx, &state)
 	// Append any diagnostics that occurred during state setting
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setObjectVersion(ctx, resp.Private, objectVersion{ETag: apiSettings.ETag})...)
 }
 
 // Update handles the update operation for the ClusterSettingsResource
@@ -405,20 +606,21 @@ func (r *ClusterSettingsResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	// Normalize ProxyLogLevel in the plan
-	if !plan.ProxyLogLevel.IsNull() && plan.ProxyLogLevel.ValueString() == "" {
-		plan.ProxyLogLevel = types.StringNull()
+	// Retrieve the version of the settings that was last read
+	version, diags := getObjectVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Validate the configuration
-	validateResp := &resource.ValidateConfigResponse{
-		Diagnostics: resp.Diagnostics,
-	}
-	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: req.Config}, validateResp)
-	resp.Diagnostics = validateResp.Diagnostics
+	// Bound the operation by the update timeout
+	timeout, diags := operationTimeout(ctx, plan.Timeouts, "update", clusterSettingsUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := withOperationTimeout(ctx, timeout)
+	defer cancel()
 
 	// Extract the ID from the plan
 	id := plan.ID.ValueString()
@@ -427,19 +629,36 @@ func (r *ClusterSettingsResource) Update(ctx context.Context, req resource.Updat
 	// Convert the plan to an UpdateClusterSettingsRequest
 	settingsReq := updateClusterSettingsRequest(plan)
 	// Call the API to update the cluster settings
-	settings, err := r.updateClusterSettings(ctx, id, settingsReq)
+	settings, err := r.updateClusterSettings(ctx, id, version.ETag, settingsReq)
 	if err != nil {
+		if errors.Is(err, errClusterSettingsChanged) {
+			resp.Diagnostics.AddError(
+				"Cluster Settings Changed Outside Terraform",
+				fmt.Sprintf("The settings of cluster %s were modified since Terraform last read them, "+
+					"for example from the console or another Terraform workspace. "+
+					"Run terraform plan again to review the current settings before applying.", id),
+			)
+			return
+		}
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error updating cluster settings", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error updating cluster settings", err, req.Plan)...)
 		return
 	}
 
 	// Update the plan with the response from the API
 	updateClusterSettingsResourceModel(&plan, settings)
+	if err := r.setJWTEndpoints(ctx, &plan); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error updating cluster settings", err.Error(), err)...)
+		return
+	}
 
 	// Set the updated plan as the new state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setObjectVersion(ctx, resp.Private, objectVersion{ETag: settings.ETag})...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, isCluster(id))...)
 }
 
 // Delete handles the deletion of a ClusterSettingsResource
@@ -458,6 +677,15 @@ func (r *ClusterSettingsResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	// Bound the operation by the delete timeout
+	timeout, diags := operationTimeout(ctx, state.Timeouts, "delete", clusterSettingsDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := withOperationTimeout(ctx, timeout)
+	defer cancel()
+
 	// Extract the ID from the state
 	id := state.ID.ValueString()
 
@@ -466,7 +694,7 @@ func (r *ClusterSettingsResource) Delete(ctx context.Context, req resource.Delet
 
 	// If there's an error during deletion, add it to the diagnostics
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting cluster settings", err.Error())
+		resp.Diagnostics.Append(errorDiagnostics("Error deleting cluster settings", err.Error(), err)...)
 		return
 	}
 
@@ -495,10 +723,16 @@ func (r *ClusterSettingsResource) ImportState(ctx context.Context, req resource.
 	var state ClusterSettingsResourceModel
 	updateClusterSettingsResourceModel(&state, settings)
 	state.ID = types.StringValue(settings.ID)
+	state.Timeouts = types.ObjectNull(timeoutsAttrTypes)
+	if err := r.setJWTEndpoints(ctx, &state); err != nil {
+		resp.Diagnostics.AddError("Error importing cluster settings", err.Error())
+		return
+	}
 
 	// Set the full state
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setObjectVersion(ctx, resp.Private, objectVersion{ETag: settings.ETag})...)
 }
 
 // API helper functions
@@ -511,6 +745,9 @@ func (r *ClusterSettingsResource) createClusterSettings(ctx context.Context, set
 
 	// Marshal the settings into JSON
 	body, err := json.Marshal(settings)
+	if err == nil {
+		body, err = mergeExtraSettings(body, settings.ExtraSettings)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling settings: %w", err)
 	}
@@ -535,7 +772,7 @@ func (r *ClusterSettingsResource) createClusterSettings(ctx context.Context, set
 	// Check if the response status code is not 201 Created
 	if resp.StatusCode != http.StatusCreated {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp.StatusCode, bodyBytes)
 	}
 
 	// Decode the response body into a ClusterSettings struct
@@ -543,6 +780,7 @@ func (r *ClusterSettingsResource) createClusterSettings(ctx context.Context, set
 	if err := json.NewDecoder(resp.Body).Decode(&createdSettings); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
+	createdSettings.ETag = resp.Header.Get("ETag")
 
 	// Return the created settings
 	return &createdSettings, nil
@@ -563,7 +801,6 @@ func (r *ClusterSettingsResource) getClusterSettings(ctx context.Context, id str
 	// Set request headers
 	req.Header.Set("Authorization", "Bearer "+r.token)
 	req.Header.Set("Content-Type", "application/json")
-	log.Printf("[DEBUG] Request headers: %+v", req.Header)
 
 	// Send the HTTP request
 	resp, err := r.client.Do(req)
@@ -574,6 +811,11 @@ func (r *ClusterSettingsResource) getClusterSettings(ctx context.Context, id str
 
 	log.Printf("[DEBUG] Response status code: %d", resp.StatusCode)
 
+	// The cluster, and with it its settings, may have been deleted
+	if isNotFoundStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("GET %s: %w", url, errNotFound)
+	}
+
 	// Check for non-OK status codes
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
@@ -581,25 +823,37 @@ func (r *ClusterSettingsResource) getClusterSettings(ctx context.Context, id str
 		return nil, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	// Decode the response body into ClusterSettings struct
+	// Decode the response body into ClusterSettings struct, keeping all settings for extra_settings
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
 	var settings ClusterSettings
-	if err := json.NewDecoder(resp.Body).Decode(&settings); err != nil {
+	if err := json.Unmarshal(body, &settings); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+	if err := json.Unmarshal(body, &settings.Raw); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
 
 	// Ensure the ID is not updated with the response ID
 	settings.ID = id
+	settings.ETag = resp.Header.Get("ETag")
 
 	return &settings, nil
 }
 
-// updateClusterSettings sends a PUT request to update existing cluster settings
-func (r *ClusterSettingsResource) updateClusterSettings(ctx context.Context, id string, settings UpdateClusterSettingsRequest) (*ClusterSettings, error) {
+// updateClusterSettings sends a PUT request to update existing cluster settings.
+// When etag is set, the update is only applied if the settings were not modified since that version.
+func (r *ClusterSettingsResource) updateClusterSettings(ctx context.Context, id string, etag string, settings UpdateClusterSettingsRequest) (*ClusterSettings, error) {
 	// Construct the API URL
 	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/settings", apiBaseURL, r.organizationID, id)
 
 	// Marshal the settings into JSON
 	body, err := json.Marshal(settings)
+	if err == nil {
+		body, err = mergeExtraSettings(body, settings.ExtraSettings)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling settings: %w", err)
 	}
@@ -613,6 +867,9 @@ func (r *ClusterSettingsResource) updateClusterSettings(ctx context.Context, id
 	// Set the necessary headers
 	req.Header.Set("Authorization", "Bearer "+r.token)
 	req.Header.Set("Content-Type", "application/json")
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
 
 	// Send the HTTP request
 	resp, err := r.client.Do(req)
@@ -621,10 +878,15 @@ func (r *ClusterSettingsResource) updateClusterSettings(ctx context.Context, id
 	}
 	defer resp.Body.Close()
 
+	// The settings were modified since the version we last read
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, errClusterSettingsChanged
+	}
+
 	// Check if the response status code is not 200 OK
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, newAPIError(resp.StatusCode, bodyBytes)
 	}
 
 	// Decode the response body into a ClusterSettings struct
@@ -632,6 +894,7 @@ func (r *ClusterSettingsResource) updateClusterSettings(ctx context.Context, id
 	if err := json.NewDecoder(resp.Body).Decode(&updatedSettings); err != nil {
 		return nil, fmt.Errorf("error decoding response: %w", err)
 	}
+	updatedSettings.ETag = resp.Header.Get("ETag")
 
 	// Return the updated settings
 	return &updatedSettings, nil
@@ -673,47 +936,66 @@ func (r *ClusterSettingsResource) deleteClusterSettings(ctx context.Context, id
 // Helper functions for request/response mapping
 // These functions help map the API request and response data to the Terraform resource model
 
-// updateClusterSettingsResourceModel updates the ClusterSettingsResourceModel with the ClusterSettings data
+// updateClusterSettingsResourceModel updates the ClusterSettingsResourceModel with the ClusterSettings data.
+// It is the single place mapping the API response to the model, used by Read, Update and ImportState.
+// Optional strings the API returns empty when unset are mapped to null, so that they match the configuration.
 func updateClusterSettingsResourceModel(model *ClusterSettingsResourceModel, settings *ClusterSettings) {
 	// Do not update the ID with the response ID, the API returns a different ID, but the ID should
 	// remain the same as the one in the state, which is the cluster ID, also known as the namespace ID.
 	// model.ID = types.StringValue(settings.ID)
 	model.Address = types.StringValue(settings.Address)
-	model.AuthenticateServiceUrl = types.StringValue(settings.AuthenticateServiceUrl)
+	model.AuthenticateServiceUrl = stringValueOrNull(settings.AuthenticateServiceUrl)
 	model.AutoApplyChangesets = types.BoolValue(settings.AutoApplyChangesets)
-	model.CookieExpire = types.StringValue(settings.CookieExpire)
+	model.CookieExpire = durationValueOrNull(settings.CookieExpire)
 	model.CookieHttpOnly = types.BoolValue(settings.CookieHttpOnly)
 	model.CookieName = types.StringValue(settings.CookieName)
-	model.DefaultUpstreamTimeout = types.StringValue(settings.DefaultUpstreamTimeout)
+	model.DefaultUpstreamTimeout = durationValueOrNull(settings.DefaultUpstreamTimeout)
 	model.DNSLookupFamily = types.StringValue(settings.DNSLookupFamily)
-	model.IdentityProvider = types.StringValue(settings.IdentityProvider)
-	model.IdentityProviderClientId = types.StringValue(settings.IdentityProviderClientId)
-	// model.IdentityProviderClientSecret = types.StringValue(settings.IdentityProviderClientSecret)
+	model.IdentityProvider = stringValueOrNull(settings.IdentityProvider)
+	model.IdentityProviderClientId = stringValueOrNull(settings.IdentityProviderClientId)
+	// The API does not return the client secret, in which case the configured secret is kept
 	if settings.IdentityProviderClientSecret != nil {
 		model.IdentityProviderClientSecret = types.StringValue(*settings.IdentityProviderClientSecret)
-	} else {
-		model.IdentityProviderClientSecret = types.StringNull()
 	}
-	model.IdentityProviderUrl = types.StringValue(settings.IdentityProviderUrl)
+	model.IdentityProviderUrl = stringValueOrNull(settings.IdentityProviderUrl)
 	model.LogLevel = types.StringValue(settings.LogLevel)
-	model.PassIdentityHeaders = types.BoolValue(settings.PassIdentityHeaders)
-	// Special handling for ProxyLogLevel
-	if settings.ProxyLogLevel == "" {
-		model.ProxyLogLevel = types.StringNull()
-	} else {
-		model.ProxyLogLevel = types.StringValue(settings.ProxyLogLevel)
-	}
-	// Note: If ProxyLogLevel is null or an empty string, it will be omitted from the request
-	model.SkipXffAppend = types.BoolValue(settings.SkipXffAppend)
-	model.TimeoutIdle = types.StringValue(settings.TimeoutIdle)
-	model.TimeoutRead = types.StringValue(settings.TimeoutRead)
-	model.TimeoutWrite = types.StringValue(settings.TimeoutWrite)
-	model.TracingSampleRate = types.Float64Value(settings.TracingSampleRate)
+	model.LogFormat = stringValueOrNull(settings.LogFormat)
+	model.AccessLogEnabled = types.BoolPointerValue(settings.AccessLogEnabled)
+	model.PassIdentityHeaders = types.BoolPointerValue(settings.PassIdentityHeaders)
+	model.ProxyLogLevel = stringValueOrNull(settings.ProxyLogLevel)
+	model.SkipXffAppend = types.BoolPointerValue(settings.SkipXffAppend)
+	model.TimeoutIdle = durationValueOrNull(settings.TimeoutIdle)
+	model.TimeoutRead = durationValueOrNull(settings.TimeoutRead)
+	model.TimeoutWrite = durationValueOrNull(settings.TimeoutWrite)
+	model.TracingSampleRate = newSampleRateValue(settings.TracingSampleRate)
+	model.PrimaryColor = stringValueOrNull(settings.PrimaryColor)
+	model.SecondaryColor = stringValueOrNull(settings.SecondaryColor)
+	model.DarkmodePrimaryColor = stringValueOrNull(settings.DarkmodePrimaryColor)
+	model.DarkmodeSecondaryColor = stringValueOrNull(settings.DarkmodeSecondaryColor)
+	model.LogoUrl = stringValueOrNull(settings.LogoUrl)
+	model.FaviconUrl = stringValueOrNull(settings.FaviconUrl)
+	model.ErrorMessageFirstParagraph = stringValueOrNull(settings.ErrorMessageFirstParagraph)
+	model.ErrorPageSupportUrl = stringValueOrNull(settings.ErrorPageSupportUrl)
+}
+
+// stringValueOrNull maps an empty string returned by the API to a null value
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// hostWithinDomain reports whether host equals domain or is a subdomain of it
+func hostWithinDomain(host, domain string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
 }
 
 // createClusterSettingsRequest creates a CreateClusterSettingsRequest from the ClusterSettingsResourceModel
 func createClusterSettingsRequest(model ClusterSettingsResourceModel) CreateClusterSettingsRequest {
-	return CreateClusterSettingsRequest{
+	req := CreateClusterSettingsRequest{
 		Address:                      model.Address.ValueString(),
 		AuthenticateServiceUrl:       model.AuthenticateServiceUrl.ValueString(),
 		AutoApplyChangesets:          model.AutoApplyChangesets.ValueBool(),
@@ -727,14 +1009,34 @@ func createClusterSettingsRequest(model ClusterSettingsResourceModel) CreateClus
 		IdentityProviderClientSecret: model.IdentityProviderClientSecret.ValueString(),
 		IdentityProviderUrl:          model.IdentityProviderUrl.ValueString(),
 		LogLevel:                     model.LogLevel.ValueString(),
-		PassIdentityHeaders:          model.PassIdentityHeaders.ValueBool(),
+		PassIdentityHeaders:          model.PassIdentityHeaders.ValueBoolPointer(),
 		ProxyLogLevel:                model.ProxyLogLevel.ValueString(),
-		SkipXffAppend:                model.SkipXffAppend.ValueBool(),
+		SkipXffAppend:                model.SkipXffAppend.ValueBoolPointer(),
 		TimeoutIdle:                  model.TimeoutIdle.ValueString(),
 		TimeoutRead:                  model.TimeoutRead.ValueString(),
 		TimeoutWrite:                 model.TimeoutWrite.ValueString(),
 		TracingSampleRate:            model.TracingSampleRate.ValueFloat64(),
+		LogFormat:                    model.LogFormat.ValueString(),
+		PrimaryColor:                 model.PrimaryColor.ValueString(),
+		SecondaryColor:               model.SecondaryColor.ValueString(),
+		DarkmodePrimaryColor:         model.DarkmodePrimaryColor.ValueString(),
+		DarkmodeSecondaryColor:       model.DarkmodeSecondaryColor.ValueString(),
+		LogoUrl:                      model.LogoUrl.ValueString(),
+		FaviconUrl:                   model.FaviconUrl.ValueString(),
+		ErrorMessageFirstParagraph:   model.ErrorMessageFirstParagraph.ValueString(),
+		ErrorPageSupportUrl:          model.ErrorPageSupportUrl.ValueString(),
 	}
+
+	// AccessLogEnabled is only sent when configured, so an explicit false is not dropped
+	if !model.AccessLogEnabled.IsNull() {
+		value := model.AccessLogEnabled.ValueBool()
+		req.AccessLogEnabled = &value
+	}
+
+	// extra_settings is validated at plan time
+	req.ExtraSettings, _ = decodeExtraSettings(model.ExtraSettings)
+
+	return req
 }
 
 // updateClusterSettingsRequest creates an UpdateClusterSettingsRequest from the ClusterSettingsResourceModel
@@ -749,14 +1051,16 @@ func updateClusterSettingsRequest(model ClusterSettingsResourceModel) UpdateClus
 		DefaultUpstreamTimeout: model.DefaultUpstreamTimeout.ValueString(),
 		DNSLookupFamily:        model.DNSLookupFamily.ValueString(),
 		LogLevel:               model.LogLevel.ValueString(),
-		PassIdentityHeaders:    model.PassIdentityHeaders.ValueBool(),
-		SkipXffAppend:          model.SkipXffAppend.ValueBool(),
 		TimeoutIdle:            model.TimeoutIdle.ValueString(),
 		TimeoutRead:            model.TimeoutRead.ValueString(),
 		TimeoutWrite:           model.TimeoutWrite.ValueString(),
 		TracingSampleRate:      model.TracingSampleRate.ValueFloat64(),
 	}
 
+	// PassIdentityHeaders and SkipXffAppend are sent as null when unset, so that the cluster default applies again
+	req.PassIdentityHeaders = model.PassIdentityHeaders.ValueBoolPointer()
+	req.SkipXffAppend = model.SkipXffAppend.ValueBoolPointer()
+
 	// For nullable fields, only include them in the request if they're not null
 	// This prevents sending empty strings or zero values when the field should be unset
 
@@ -787,12 +1091,30 @@ func updateClusterSettingsRequest(model ClusterSettingsResourceModel) UpdateClus
 		req.IdentityProviderUrl = model.IdentityProviderUrl.ValueString()
 	}
 
-	// Special handling for ProxyLogLevel
-	// Only include it in the request if it's not null and not an empty string
-	if !model.ProxyLogLevel.IsNull() && model.ProxyLogLevel.ValueString() != "" {
+	// LogFormat and AccessLogEnabled are sent as null when unset, which resets them to their defaults
+	req.LogFormat = model.LogFormat.ValueStringPointer()
+	req.AccessLogEnabled = model.AccessLogEnabled.ValueBoolPointer()
+
+	// Branding options are sent as null when unset, which restores the default branding
+	req.PrimaryColor = model.PrimaryColor.ValueStringPointer()
+	req.SecondaryColor = model.SecondaryColor.ValueStringPointer()
+	req.DarkmodePrimaryColor = model.DarkmodePrimaryColor.ValueStringPointer()
+	req.DarkmodeSecondaryColor = model.DarkmodeSecondaryColor.ValueStringPointer()
+	req.LogoUrl = model.LogoUrl.ValueStringPointer()
+	req.FaviconUrl = model.FaviconUrl.ValueStringPointer()
+
+	// Error page options are sent as null when unset, which restores the default error page
+	req.ErrorMessageFirstParagraph = model.ErrorMessageFirstParagraph.ValueStringPointer()
+	req.ErrorPageSupportUrl = model.ErrorPageSupportUrl.ValueStringPointer()
+
+	// ProxyLogLevel
+	// The API does not accept null, so an unset value is omitted and the current value is kept
+	if !model.ProxyLogLevel.IsNull() {
 		req.ProxyLogLevel = model.ProxyLogLevel.ValueString()
 	}
-	// Note: If ProxyLogLevel is null or an empty string, it will be omitted from the request
+
+	// extra_settings is validated at plan time
+	req.ExtraSettings, _ = decodeExtraSettings(model.ExtraSettings)
 
 	return req
 }
@@ -815,13 +1137,25 @@ type CreateClusterSettingsRequest struct {
 	IdentityProviderClientSecret string  `json:"identityProviderClientSecret,omitempty"`
 	IdentityProviderUrl          string  `json:"identityProviderUrl,omitempty"`
 	LogLevel                     string  `json:"logLevel,omitempty"`
-	PassIdentityHeaders          bool    `json:"passIdentityHeaders,omitempty"`
+	PassIdentityHeaders          *bool   `json:"passIdentityHeaders,omitempty"`
 	ProxyLogLevel                string  `json:"proxyLogLevel,omitempty"`
-	SkipXffAppend                bool    `json:"skipXffAppend,omitempty"`
+	SkipXffAppend                *bool   `json:"skipXffAppend,omitempty"`
 	TimeoutIdle                  string  `json:"timeoutIdle,omitempty"`
 	TimeoutRead                  string  `json:"timeoutRead,omitempty"`
 	TimeoutWrite                 string  `json:"timeoutWrite,omitempty"`
 	TracingSampleRate            float64 `json:"tracingSampleRate,omitempty"`
+	LogFormat                    string  `json:"logFormat,omitempty"`
+	AccessLogEnabled             *bool   `json:"accessLogEnabled,omitempty"`
+	PrimaryColor                 string  `json:"primaryColor,omitempty"`
+	SecondaryColor               string  `json:"secondaryColor,omitempty"`
+	DarkmodePrimaryColor         string  `json:"darkmodePrimaryColor,omitempty"`
+	DarkmodeSecondaryColor       string  `json:"darkmodeSecondaryColor,omitempty"`
+	LogoUrl                      string  `json:"logoUrl,omitempty"`
+	FaviconUrl                   string  `json:"faviconUrl,omitempty"`
+	ErrorMessageFirstParagraph   string  `json:"errorMessageFirstParagraph,omitempty"`
+	ErrorPageSupportUrl          string  `json:"errorPageSupportUrl,omitempty"`
+	// ExtraSettings are merged into the request body
+	ExtraSettings map[string]json.RawMessage `json:"-"`
 }
 
 // UpdateClusterSettingsRequest is used to update existing cluster settings
@@ -839,13 +1173,25 @@ type UpdateClusterSettingsRequest struct {
 	IdentityProviderClientSecret *string `json:"identityProviderClientSecret,omitempty"`
 	IdentityProviderUrl          string  `json:"identityProviderUrl,omitempty"`
 	LogLevel                     string  `json:"logLevel,omitempty"`
-	PassIdentityHeaders          bool    `json:"passIdentityHeaders"`
+	PassIdentityHeaders          *bool   `json:"passIdentityHeaders"`
 	ProxyLogLevel                string  `json:"proxyLogLevel,omitempty"`
-	SkipXffAppend                bool    `json:"skipXffAppend"`
+	SkipXffAppend                *bool   `json:"skipXffAppend"`
 	TimeoutIdle                  string  `json:"timeoutIdle,omitempty"`
 	TimeoutRead                  string  `json:"timeoutRead,omitempty"`
 	TimeoutWrite                 string  `json:"timeoutWrite,omitempty"`
 	TracingSampleRate            float64 `json:"tracingSampleRate,omitempty"`
+	LogFormat                    *string `json:"logFormat"`
+	AccessLogEnabled             *bool   `json:"accessLogEnabled"`
+	PrimaryColor                 *string `json:"primaryColor"`
+	SecondaryColor               *string `json:"secondaryColor"`
+	DarkmodePrimaryColor         *string `json:"darkmodePrimaryColor"`
+	DarkmodeSecondaryColor       *string `json:"darkmodeSecondaryColor"`
+	LogoUrl                      *string `json:"logoUrl"`
+	FaviconUrl                   *string `json:"faviconUrl"`
+	ErrorMessageFirstParagraph   *string `json:"errorMessageFirstParagraph"`
+	ErrorPageSupportUrl          *string `json:"errorPageSupportUrl"`
+	// ExtraSettings are merged into the request body
+	ExtraSettings map[string]json.RawMessage `json:"-"`
 }
 
 // ClusterSettings represents the cluster settings data returned by the API
@@ -864,11 +1210,25 @@ type ClusterSettings struct {
 	IdentityProviderClientSecret *string `json:"identityProviderClientSecret"`
 	IdentityProviderUrl          string  `json:"identityProviderUrl"`
 	LogLevel                     string  `json:"logLevel"`
-	PassIdentityHeaders          bool    `json:"passIdentityHeaders"`
+	PassIdentityHeaders          *bool   `json:"passIdentityHeaders"`
 	ProxyLogLevel                string  `json:"proxyLogLevel"`
-	SkipXffAppend                bool    `json:"skipXffAppend"`
+	SkipXffAppend                *bool   `json:"skipXffAppend"`
 	TimeoutIdle                  string  `json:"timeoutIdle"`
 	TimeoutRead                  string  `json:"timeoutRead"`
 	TimeoutWrite                 string  `json:"timeoutWrite"`
 	TracingSampleRate            float64 `json:"tracingSampleRate"`
+	LogFormat                    string  `json:"logFormat"`
+	AccessLogEnabled             *bool   `json:"accessLogEnabled"`
+	PrimaryColor                 string  `json:"primaryColor"`
+	SecondaryColor               string  `json:"secondaryColor"`
+	DarkmodePrimaryColor         string  `json:"darkmodePrimaryColor"`
+	DarkmodeSecondaryColor       string  `json:"darkmodeSecondaryColor"`
+	LogoUrl                      string  `json:"logoUrl"`
+	FaviconUrl                   string  `json:"faviconUrl"`
+	ErrorMessageFirstParagraph   string  `json:"errorMessageFirstParagraph"`
+	ErrorPageSupportUrl          string  `json:"errorPageSupportUrl"`
+	// ETag is taken from the response headers rather than the body
+	ETag string `json:"-"`
+	// Raw holds all settings of the response, including those without a field, for extra_settings
+	Raw map[string]json.RawMessage `json:"-"`
 }
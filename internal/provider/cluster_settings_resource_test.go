@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newClusterSettingsTestServer returns a resource sending its requests to a fake API, which stores the
+// settings of the last update. Fields set to null by an update are reset, i.e. no longer returned.
+func newClusterSettingsTestServer(t *testing.T) *ClusterSettingsResource {
+	t.Helper()
+
+	var mu sync.Mutex
+	stored := map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Method == http.MethodPut {
+			var update map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for key, value := range update {
+				if value == nil {
+					delete(stored, key)
+					continue
+				}
+				stored[key] = value
+			}
+		}
+		w.Header().Set("ETag", `"1"`)
+		_ = json.NewEncoder(w).Encode(stored)
+	}))
+	t.Cleanup(server.Close)
+
+	transport, err := newAPIURLTransport(http.DefaultTransport, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ClusterSettingsResource{client: &http.Client{Transport: transport}, token: "token", organizationID: "org-1"}
+}
+
+// clusterSettingsTestModel returns the configuration of cluster settings that only sets the required attributes.
+func clusterSettingsTestModel() ClusterSettingsResourceModel {
+	return ClusterSettingsResourceModel{
+		ID:         types.StringValue("cluster-1"),
+		Address:    types.StringValue(":443"),
+		LogLevel:   types.StringValue("info"),
+		Timeouts:   types.ObjectNull(timeoutsAttrTypes),
+		CookieName: types.StringValue("_pomerium"),
+	}
+}
+
+func TestClusterSettingsUpdateClearsRemovedAttributes(t *testing.T) {
+	tests := []struct {
+		name string
+		// set sets the attribute in the configuration
+		set func(model *ClusterSettingsResourceModel)
+		// value returns the attribute from the state
+		value func(model ClusterSettingsResourceModel) attr.Value
+	}{
+		{
+			name:  "log_format",
+			set:   func(model *ClusterSettingsResourceModel) { model.LogFormat = types.StringValue("json") },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.LogFormat },
+		},
+		{
+			name:  "access_log_enabled",
+			set:   func(model *ClusterSettingsResourceModel) { model.AccessLogEnabled = types.BoolValue(false) },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.AccessLogEnabled },
+		},
+		{
+			name:  "pass_identity_headers",
+			set:   func(model *ClusterSettingsResourceModel) { model.PassIdentityHeaders = types.BoolValue(true) },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.PassIdentityHeaders },
+		},
+		{
+			name:  "skip_xff_append",
+			set:   func(model *ClusterSettingsResourceModel) { model.SkipXffAppend = types.BoolValue(false) },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.SkipXffAppend },
+		},
+		{
+			name:  "primary_color",
+			set:   func(model *ClusterSettingsResourceModel) { model.PrimaryColor = types.StringValue("#112233") },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.PrimaryColor },
+		},
+		{
+			name:  "secondary_color",
+			set:   func(model *ClusterSettingsResourceModel) { model.SecondaryColor = types.StringValue("#445566") },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.SecondaryColor },
+		},
+		{
+			name:  "darkmode_primary_color",
+			set:   func(model *ClusterSettingsResourceModel) { model.DarkmodePrimaryColor = types.StringValue("#778899") },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.DarkmodePrimaryColor },
+		},
+		{
+			name:  "darkmode_secondary_color",
+			set:   func(model *ClusterSettingsResourceModel) { model.DarkmodeSecondaryColor = types.StringValue("#aabbcc") },
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.DarkmodeSecondaryColor },
+		},
+		{
+			name: "logo_url",
+			set: func(model *ClusterSettingsResourceModel) {
+				model.LogoUrl = types.StringValue("https://example.com/logo.svg")
+			},
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.LogoUrl },
+		},
+		{
+			name: "favicon_url",
+			set: func(model *ClusterSettingsResourceModel) {
+				model.FaviconUrl = types.StringValue("https://example.com/favicon.ico")
+			},
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.FaviconUrl },
+		},
+		{
+			name: "error_message_first_paragraph",
+			set: func(model *ClusterSettingsResourceModel) {
+				model.ErrorMessageFirstParagraph = types.StringValue("Ask [the platform team](https://example.com/access) for access.")
+			},
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.ErrorMessageFirstParagraph },
+		},
+		{
+			name: "error_page_support_url",
+			set: func(model *ClusterSettingsResourceModel) {
+				model.ErrorPageSupportUrl = types.StringValue("https://example.com/support")
+			},
+			value: func(model ClusterSettingsResourceModel) attr.Value { return model.ErrorPageSupportUrl },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newClusterSettingsTestServer(t)
+			ctx := context.Background()
+
+			// Apply the configuration setting the attribute
+			config := clusterSettingsTestModel()
+			tt.set(&config)
+			settings, err := r.updateClusterSettings(ctx, "cluster-1", "", updateClusterSettingsRequest(config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			state := config
+			updateClusterSettingsResourceModel(&state, settings)
+			if want, got := tt.value(config), tt.value(state); !got.Equal(want) {
+				t.Fatalf("expected the state to hold the configured %s, got %s", want, got)
+			}
+
+			// Apply the configuration without the attribute, whose result must match it
+			config = clusterSettingsTestModel()
+			settings, err = r.updateClusterSettings(ctx, "cluster-1", "", updateClusterSettingsRequest(config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			updateClusterSettingsResourceModel(&state, settings)
+			if got := tt.value(state); !got.IsNull() {
+				t.Errorf("expected the removed attribute to be cleared, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterStatusDataSource{}
+
+// NewClusterStatusDataSource creates a new ClusterStatusDataSource.
+func NewClusterStatusDataSource() datasource.DataSource {
+	return &ClusterStatusDataSource{}
+}
+
+// ClusterStatusDataSource defines the data source implementation.
+type ClusterStatusDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ClusterStatusDataSourceModel describes the data source data model.
+type ClusterStatusDataSourceModel struct {
+	ClusterID          types.String `tfsdk:"cluster_id"`
+	Status             types.String `tfsdk:"status"`
+	Healthy            types.Bool   `tfsdk:"healthy"`
+	ConnectedInstances types.Int64  `tfsdk:"connected_instances"`
+	PomeriumVersion    types.String `tfsdk:"pomerium_version"`
+	LastHeartbeatAt    types.String `tfsdk:"last_heartbeat_at"`
+	CertificateStatus  types.String `tfsdk:"certificate_status"`
+}
+
+// Metadata sets the data source type name for the ClusterStatusDataSource.
+func (d *ClusterStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_status"
+}
+
+// Schema defines the structure and attributes of the ClusterStatusDataSource.
+func (d *ClusterStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the health of a cluster, e.g. to gate a deployment pipeline on a healthy cluster.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster.",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the cluster, e.g. `healthy`, `degraded` or `offline`.",
+				Computed:            true,
+			},
+			"healthy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the status of the cluster is `healthy`.",
+				Computed:            true,
+			},
+			"connected_instances": schema.Int64Attribute{
+				MarkdownDescription: "The number of Pomerium instances connected to the cluster.",
+				Computed:            true,
+			},
+			"pomerium_version": schema.StringAttribute{
+				MarkdownDescription: "The version of Pomerium the connected instances are running.",
+				Computed:            true,
+			},
+			"last_heartbeat_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the last heartbeat received from the cluster.",
+				Computed:            true,
+			},
+			"certificate_status": schema.StringAttribute{
+				MarkdownDescription: "The status of the certificates of the cluster, e.g. `valid`, `expiring` or `expired`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ClusterStatusDataSource.
+func (d *ClusterStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read retrieves the health of the cluster.
+func (d *ClusterStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/status", apiBaseURL, d.organizationID, data.ClusterID.ValueString())
+
+	var status ClusterStatus
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &status); err != nil {
+		resp.Diagnostics.AddError("Error fetching cluster status", err.Error())
+		return
+	}
+
+	data.Status = types.StringValue(status.Status)
+	data.Healthy = types.BoolValue(status.Status == "healthy")
+	data.ConnectedInstances = types.Int64Value(status.ConnectedInstances)
+	data.PomeriumVersion = stringValueOrNull(status.PomeriumVersion)
+	data.LastHeartbeatAt = stringValueOrNull(status.LastHeartbeatAt)
+	data.CertificateStatus = stringValueOrNull(status.CertificateStatus)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
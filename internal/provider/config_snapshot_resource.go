@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ConfigSnapshotResource{}
+var _ resource.ResourceWithImportState = &ConfigSnapshotResource{}
+
+// NewConfigSnapshotResource creates a new ConfigSnapshotResource.
+func NewConfigSnapshotResource() resource.Resource {
+	return &ConfigSnapshotResource{}
+}
+
+// ConfigSnapshotResource defines the resource implementation.
+// Creating the resource captures the configuration of a cluster. Changing restore_trigger restores it.
+type ConfigSnapshotResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ConfigSnapshotResourceModel describes the resource data model.
+type ConfigSnapshotResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	ClusterID        types.String `tfsdk:"cluster_id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	RestoreTrigger   types.String `tfsdk:"restore_trigger"`
+	Revision         types.String `tfsdk:"revision"`
+	RestoredRevision types.String `tfsdk:"restored_revision"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
+
+// Metadata sets the resource type name for the ConfigSnapshotResource.
+func (r *ConfigSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config_snapshot"
+}
+
+// Schema defines the structure and attributes of the ConfigSnapshotResource.
+func (r *ConfigSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Captures a named snapshot of the full configuration of a Pomerium Zero cluster, e.g. before a risky policy refactor. " +
+			"The snapshot is taken when the resource is created. Setting or changing `restore_trigger` restores the cluster configuration " +
+			"to the snapshot. Destroying the resource deletes the snapshot.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the snapshot.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to take a snapshot of.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the snapshot.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description of the snapshot.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"restore_trigger": schema.StringAttribute{
+				MarkdownDescription: "An arbitrary value that restores the snapshot when set or changed after the snapshot was taken, " +
+					"e.g. an incident ticket number. Setting it when the snapshot is taken does not restore it.",
+				Optional: true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The revision of the cluster configuration captured by the snapshot.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"restored_revision": schema.StringAttribute{
+				MarkdownDescription: "The revision of the cluster configuration after the snapshot was last restored.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp at which the snapshot was taken.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ConfigSnapshotResource.
+func (r *ConfigSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create takes the snapshot.
+func (r *ConfigSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ConfigSnapshotResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/snapshots", apiBaseURL, r.organizationID, plan.ClusterID.ValueString())
+	snapshotReq := ConfigSnapshot{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
+
+	var snapshot ConfigSnapshot
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, snapshotReq, http.StatusCreated, &snapshot); err != nil {
+		resp.Diagnostics.AddError("Error creating config snapshot", err.Error())
+		return
+	}
+
+	updateConfigSnapshotResourceModel(&plan, &snapshot)
+	plan.RestoredRevision = types.StringNull()
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the snapshot.
+func (r *ConfigSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ConfigSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.getConfigSnapshot(ctx, state.ClusterID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading config snapshot", err.Error())
+		return
+	}
+
+	updateConfigSnapshotResourceModel(&state, snapshot)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update restores the snapshot when restore_trigger changed, which is the only attribute that can be updated.
+func (r *ConfigSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ConfigSnapshotResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.RestoredRevision = state.RestoredRevision
+
+	// Removing the trigger does not restore the snapshot
+	if !plan.RestoreTrigger.IsNull() && !plan.RestoreTrigger.Equal(state.RestoreTrigger) {
+		url := fmt.Sprintf("%s/organizations/%s/clusters/%s/snapshots/%s/restore", apiBaseURL, r.organizationID, plan.ClusterID.ValueString(), plan.ID.ValueString())
+
+		var result struct {
+			Revision string `json:"revision"`
+		}
+		if err := doAPIRequest(ctx, r.client, r.token, "POST", url, nil, http.StatusOK, &result); err != nil {
+			resp.Diagnostics.AddError("Error restoring config snapshot", err.Error())
+			return
+		}
+		plan.RestoredRevision = types.StringValue(result.Revision)
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the snapshot. The cluster configuration is not changed.
+func (r *ConfigSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ConfigSnapshotResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/snapshots/%s", apiBaseURL, r.organizationID, state.ClusterID.ValueString(), state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting config snapshot", err.Error())
+		return
+	}
+}
+
+// ImportState imports a snapshot by the cluster ID and the snapshot ID, separated by a slash.
+func (r *ConfigSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, snapshotID, ok := strings.Cut(req.ID, "/")
+	if !ok || clusterID == "" || snapshotID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <cluster_id>/<snapshot_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	snapshot, err := r.getConfigSnapshot(ctx, clusterID, snapshotID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing config snapshot", fmt.Sprintf("Unable to read config snapshot %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := ConfigSnapshotResourceModel{
+		ClusterID:        types.StringValue(clusterID),
+		RestoreTrigger:   types.StringNull(),
+		RestoredRevision: types.StringNull(),
+	}
+	updateConfigSnapshotResourceModel(&state, snapshot)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getConfigSnapshot fetches a snapshot by its ID.
+func (r *ConfigSnapshotResource) getConfigSnapshot(ctx context.Context, clusterID, snapshotID string) (*ConfigSnapshot, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/snapshots/%s", apiBaseURL, r.organizationID, clusterID, snapshotID)
+
+	var snapshot ConfigSnapshot
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// updateConfigSnapshotResourceModel updates the model with the snapshot returned by the API.
+func updateConfigSnapshotResourceModel(model *ConfigSnapshotResourceModel, snapshot *ConfigSnapshot) {
+	model.ID = types.StringValue(snapshot.ID)
+	model.Name = types.StringValue(snapshot.Name)
+	model.Description = stringValueOrNull(snapshot.Description)
+	model.Revision = types.StringValue(snapshot.Revision)
+	model.CreatedAt = types.StringValue(snapshot.CreatedAt)
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ConsoleTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ConsoleTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ConsoleTokenEphemeralResource{}
+
+// consoleTokenPrivateKey is the private data key holding the ID of the minted token, used to revoke it on close.
+const consoleTokenPrivateKey = "token_id"
+
+// NewConsoleTokenEphemeralResource creates a new ConsoleTokenEphemeralResource.
+func NewConsoleTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ConsoleTokenEphemeralResource{}
+}
+
+// ConsoleTokenEphemeralResource defines the ephemeral resource implementation.
+// The token is minted when the ephemeral resource is opened, and revoked when it is closed at the end of the run.
+type ConsoleTokenEphemeralResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ConsoleTokenEphemeralResourceModel describes the ephemeral resource data model.
+type ConsoleTokenEphemeralResourceModel struct {
+	Role        types.String `tfsdk:"role"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	TTL         types.String `tfsdk:"ttl"`
+	Token       types.String `tfsdk:"token"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// Metadata sets the ephemeral resource type name for the ConsoleTokenEphemeralResource.
+func (r *ConsoleTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_console_token"
+}
+
+// Schema defines the structure and attributes of the ConsoleTokenEphemeralResource.
+func (r *ConsoleTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exchanges the provider credentials for a scoped, short-lived Pomerium Zero console API token, " +
+			"e.g. for provisioners or external scripts that call the API during the same run. " +
+			"The token is not persisted in the Terraform state, and is revoked at the end of the run. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role the token is limited to. One of `admin`, `editor` or `viewer`. Defaults to `viewer`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(roles...),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace the token is limited to. When not set, the token is valid for the whole organization.",
+				Optional:            true,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the token is valid, e.g. `15m`. When not set, the API default is used.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The console API token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp after which the token is no longer valid.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ConsoleTokenEphemeralResource.
+func (r *ConsoleTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Open mints a new console API token.
+func (r *ConsoleTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ConsoleTokenEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := "viewer"
+	if !data.Role.IsNull() {
+		role = data.Role.ValueString()
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/access-tokens", apiBaseURL, r.organizationID)
+	body := struct {
+		Role        string `json:"role"`
+		NamespaceID string `json:"namespaceId,omitempty"`
+		TTL         string `json:"ttl,omitempty"`
+	}{
+		Role:        role,
+		NamespaceID: data.NamespaceID.ValueString(),
+		TTL:         data.TTL.ValueString(),
+	}
+
+	var accessToken AccessToken
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, body, http.StatusCreated, &accessToken); err != nil {
+		resp.Diagnostics.AddError("Error creating console token", err.Error())
+		return
+	}
+
+	tokenID, err := json.Marshal(accessToken.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating console token", fmt.Sprintf("Unable to store the token ID: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, consoleTokenPrivateKey, tokenID)...)
+
+	data.Token = types.StringValue(accessToken.Token)
+	data.ExpiresAt = types.StringValue(accessToken.ExpiresAt)
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Close revokes the console API token minted by Open.
+func (r *ConsoleTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	data, diags := req.Private.GetKey(ctx, consoleTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+
+	var tokenID string
+	if err := json.Unmarshal(data, &tokenID); err != nil {
+		resp.Diagnostics.AddError("Error revoking console token", fmt.Sprintf("Unable to read the token ID: %s", err))
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/access-tokens/%s", apiBaseURL, r.organizationID, tokenID)
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil && !errors.Is(err, errNotFound) {
+		resp.Diagnostics.AddError("Error revoking console token", err.Error())
+	}
+}
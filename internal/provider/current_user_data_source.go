@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CurrentUserDataSource{}
+
+// NewCurrentUserDataSource creates a new CurrentUserDataSource.
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &CurrentUserDataSource{}
+}
+
+// CurrentUserDataSource defines the data source implementation.
+type CurrentUserDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// CurrentUserDataSourceModel describes the data source data model.
+type CurrentUserDataSourceModel struct {
+	Subject       types.String                   `tfsdk:"subject"`
+	Email         types.String                   `tfsdk:"email"`
+	Name          types.String                   `tfsdk:"name"`
+	Roles         []types.String                 `tfsdk:"roles"`
+	Organizations []CurrentUserOrganizationModel `tfsdk:"organizations"`
+}
+
+// CurrentUserOrganizationModel describes an organization the current user is a member of.
+type CurrentUserOrganizationModel struct {
+	ID    types.String   `tfsdk:"id"`
+	Name  types.String   `tfsdk:"name"`
+	Roles []types.String `tfsdk:"roles"`
+}
+
+// Metadata sets the data source type name for the CurrentUserDataSource.
+func (d *CurrentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_current_user"
+}
+
+// Schema defines the structure and attributes of the CurrentUserDataSource.
+func (d *CurrentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exposes the identity behind the API token the provider is configured with, " +
+			"e.g. to check permissions before applying changes or to debug authentication issues.",
+		Attributes: map[string]schema.Attribute{
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "The subject of the identity, i.e. the ID of the user or service account.",
+				Computed:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the identity. Not set for service accounts.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the identity.",
+				Computed:            true,
+			},
+			"roles": schema.ListAttribute{
+				MarkdownDescription: "The roles of the identity in the organization the provider manages.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"organizations": schema.ListNestedAttribute{
+				MarkdownDescription: "The organizations the identity is a member of.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the organization.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the organization.",
+							Computed:            true,
+						},
+						"roles": schema.ListAttribute{
+							MarkdownDescription: "The roles of the identity in the organization.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the CurrentUserDataSource.
+func (d *CurrentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read retrieves the identity behind the API token.
+func (d *CurrentUserDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	url := fmt.Sprintf("%s/user", apiBaseURL)
+
+	var user CurrentUser
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &user); err != nil {
+		resp.Diagnostics.AddError("Error fetching current user", err.Error())
+		return
+	}
+
+	data := CurrentUserDataSourceModel{
+		Subject:       types.StringValue(user.Subject),
+		Email:         stringValueOrNull(user.Email),
+		Name:          stringValueOrNull(user.Name),
+		Roles:         []types.String{},
+		Organizations: []CurrentUserOrganizationModel{},
+	}
+	for _, organization := range user.Organizations {
+		roles := make([]types.String, 0, len(organization.Roles))
+		for _, role := range organization.Roles {
+			roles = append(roles, types.StringValue(role))
+		}
+
+		if organization.ID == d.organizationID {
+			data.Roles = roles
+		}
+		data.Organizations = append(data.Organizations, CurrentUserOrganizationModel{
+			ID:    types.StringValue(organization.ID),
+			Name:  types.StringValue(organization.Name),
+			Roles: roles,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema of the deletion_protection attribute, which makes
+// Delete fail while it is set. The attribute is only stored in the Terraform state.
+func deletionProtectionAttribute(resourceName string) resource_schema.BoolAttribute {
+	return resource_schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		MarkdownDescription: fmt.Sprintf("If set to `true`, Terraform refuses to delete the %s, e.g. on `terraform destroy` "+
+			"or when the resource is replaced or removed from the configuration. Set it to `false` and apply before deleting the %s.",
+			resourceName, resourceName),
+	}
+}
+
+// checkDeletionProtection returns an error diagnostic when deletion protection is enabled in the state.
+func checkDeletionProtection(deletionProtection types.Bool, resourceName, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if deletionProtection.ValueBool() {
+		diags.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("The %s %q has deletion_protection set to true and cannot be deleted. "+
+				"Set deletion_protection to false and apply the change before deleting the %s.", resourceName, name, resourceName),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DevicesDataSource{}
+
+var (
+	// deviceTypes lists the types of devices that can be enrolled.
+	deviceTypes = []string{"secure_enclave", "tpm", "webauthn"}
+
+	// deviceApprovalStates lists the approval states of enrolled devices.
+	deviceApprovalStates = []string{"pending", "approved", "rejected"}
+)
+
+// NewDevicesDataSource creates a new DevicesDataSource.
+func NewDevicesDataSource() datasource.DataSource {
+	return &DevicesDataSource{}
+}
+
+// DevicesDataSource defines the data source implementation.
+type DevicesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// DevicesDataSourceModel describes the data source data model.
+type DevicesDataSourceModel struct {
+	OwnerID       types.String      `tfsdk:"owner_id"`
+	Type          types.String      `tfsdk:"type"`
+	ApprovalState types.String      `tfsdk:"approval_state"`
+	Devices       []DeviceDataModel `tfsdk:"devices"`
+}
+
+// DeviceDataModel describes a single device returned by the DevicesDataSource.
+type DeviceDataModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	OwnerID       types.String `tfsdk:"owner_id"`
+	OwnerEmail    types.String `tfsdk:"owner_email"`
+	Type          types.String `tfsdk:"type"`
+	ApprovalState types.String `tfsdk:"approval_state"`
+	EnrolledAt    types.String `tfsdk:"enrolled_at"`
+}
+
+// Metadata sets the data source type name for the DevicesDataSource.
+func (d *DevicesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_devices"
+}
+
+// Schema defines the structure and attributes of the DevicesDataSource.
+func (d *DevicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the devices enrolled in the organization, e.g. to reconcile device approvals against an inventory system. " +
+			"All filters are optional and are combined.",
+		Attributes: map[string]schema.Attribute{
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "Only list the devices of this user.",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list the devices of this type. One of `secure_enclave`, `tpm` or `webauthn`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(deviceTypes...),
+				},
+			},
+			"approval_state": schema.StringAttribute{
+				MarkdownDescription: "Only list the devices in this approval state. One of `pending`, `approved` or `rejected`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(deviceApprovalStates...),
+				},
+			},
+			"devices": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching devices.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the device.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the device.",
+							Computed:            true,
+						},
+						"owner_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the user who enrolled the device.",
+							Computed:            true,
+						},
+						"owner_email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the user who enrolled the device.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the device.",
+							Computed:            true,
+						},
+						"approval_state": schema.StringAttribute{
+							MarkdownDescription: "The approval state of the device.",
+							Computed:            true,
+						},
+						"enrolled_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp at which the device was enrolled.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the DevicesDataSource.
+func (d *DevicesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the devices matching the filters.
+func (d *DevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DevicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.OwnerID.IsNull() {
+		query.Set("ownerId", data.OwnerID.ValueString())
+	}
+	if !data.Type.IsNull() {
+		query.Set("type", data.Type.ValueString())
+	}
+	if !data.ApprovalState.IsNull() {
+		query.Set("approvalState", data.ApprovalState.ValueString())
+	}
+
+	devicesURL := fmt.Sprintf("%s/organizations/%s/devices", apiBaseURL, d.organizationID)
+	if len(query) > 0 {
+		devicesURL += "?" + query.Encode()
+	}
+
+	var devices []Device
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", devicesURL, nil, http.StatusOK, &devices); err != nil {
+		resp.Diagnostics.AddError("Error fetching devices", err.Error())
+		return
+	}
+
+	data.Devices = []DeviceDataModel{}
+	for _, device := range devices {
+		data.Devices = append(data.Devices, DeviceDataModel{
+			ID:            types.StringValue(device.ID),
+			Name:          stringValueOrNull(device.Name),
+			OwnerID:       stringValueOrNull(device.OwnerID),
+			OwnerEmail:    stringValueOrNull(device.OwnerEmail),
+			Type:          types.StringValue(device.Type),
+			ApprovalState: types.StringValue(device.ApprovalState),
+			EnrolledAt:    stringValueOrNull(device.EnrolledAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
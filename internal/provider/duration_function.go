@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DurationFunction{}
+
+// NewDurationFunction creates a new DurationFunction.
+func NewDurationFunction() function.Function {
+	return &DurationFunction{}
+}
+
+// DurationFunction validates and normalizes a Go duration string.
+type DurationFunction struct{}
+
+// Metadata sets the name of the DurationFunction.
+func (f *DurationFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "duration"
+}
+
+// Definition defines the parameters and return type of the DurationFunction.
+func (f *DurationFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates and normalizes a duration",
+		MarkdownDescription: "Validates a duration such as `90m` or `1h30m` and returns it in the canonical form used by " +
+			"Pomerium, e.g. `1h30m0s`, for attributes such as `cookie_expire` and the route timeouts. Valid units are " +
+			"`ns`, `us`, `ms`, `s`, `m` and `h`. An error is raised for invalid or negative durations.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "duration",
+				MarkdownDescription: "The duration to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the duration.
+func (f *DurationFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid duration %q: expected a duration such as \"30s\", \"5m\" or \"1h30m\".", value))
+		return
+	}
+	if duration < 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid duration %q: must not be negative.", value))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, duration.String())
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = durationType{}
+	_ basetypes.StringValuableWithSemanticEquals = durationValue{}
+)
+
+// durationType is the type of string attributes holding a Go duration, such as `24h`. The API
+// normalizes durations, e.g. it returns `24h0m0s` for `24h`, so values of this type are equal
+// when they describe the same duration, regardless of how they are written.
+type durationType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable name of the type.
+func (t durationType) String() string {
+	return "durationType"
+}
+
+// Equal reports whether o is also a durationType.
+func (t durationType) Equal(o attr.Type) bool {
+	other, ok := o.(durationType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueType returns the value type of this type.
+func (t durationType) ValueType(_ context.Context) attr.Value {
+	return durationValue{}
+}
+
+// ValueFromString converts a string value to a durationValue.
+func (t durationType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return durationValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value to a durationValue.
+func (t durationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return durationValue{StringValue: stringValue}, nil
+}
+
+// durationValue is a value of durationType.
+type durationValue struct {
+	basetypes.StringValue
+}
+
+// durationNull returns a null duration.
+func durationNull() durationValue {
+	return durationValue{StringValue: basetypes.NewStringNull()}
+}
+
+// durationValueOrNull returns a duration holding s, or a null duration when s is empty.
+func durationValueOrNull(s string) durationValue {
+	if s == "" {
+		return durationNull()
+	}
+	return durationValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+// Type returns the type of the value.
+func (v durationValue) Type(_ context.Context) attr.Type {
+	return durationType{}
+}
+
+// Equal reports whether o is a durationValue written exactly the same way. Use StringSemanticEquals
+// to compare the durations themselves.
+func (v durationValue) Equal(o attr.Value) bool {
+	other, ok := o.(durationValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values describe the same duration. Terraform then keeps
+// the value as written in the configuration, instead of reporting the normalized value as a change.
+func (v durationValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(durationValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return sameDuration(v.ValueString(), newValue.ValueString()), diags
+}
+
+// sameDuration reports whether a and b are valid durations of the same length, e.g. 24h and 24h0m0s.
+func sameDuration(a, b string) bool {
+	durationA, errA := time.ParseDuration(a)
+	durationB, errB := time.ParseDuration(b)
+	return errA == nil && errB == nil && durationA == durationB
+}
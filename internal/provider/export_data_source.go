@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// redactedValue replaces the values of secrets in exports with redact_secrets set.
+const redactedValue = "REDACTED"
+
+// secretKeyParts are the parts of the keys of API objects that hold secrets, e.g. identityProviderClientSecret
+// of cluster settings and kubernetesServiceAccountToken of routes. Keys are compared in lower case.
+var secretKeyParts = []string{"secret", "token", "password", "privatekey"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ExportDataSource{}
+
+// NewExportDataSource creates a new ExportDataSource.
+func NewExportDataSource() datasource.DataSource {
+	return &ExportDataSource{}
+}
+
+// ExportDataSource defines the data source implementation.
+type ExportDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ExportDataSourceModel describes the data source data model.
+type ExportDataSourceModel struct {
+	RedactSecrets types.Bool   `tfsdk:"redact_secrets"`
+	JSON          types.String `tfsdk:"json"`
+}
+
+// organizationExport is the document exported by the ExportDataSource. Objects are kept as returned
+// by the API, so that the export holds every field, including those the provider does not manage.
+type organizationExport struct {
+	OrganizationID string                 `json:"organizationId"`
+	Clusters       []interface{}          `json:"clusters"`
+	Settings       map[string]interface{} `json:"settings"`
+	Routes         []interface{}          `json:"routes"`
+	Policies       []interface{}          `json:"policies"`
+}
+
+// Metadata sets the data source type name for the ExportDataSource.
+func (d *ExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export"
+}
+
+// Schema defines the structure and attributes of the ExportDataSource.
+func (d *ExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the configuration of the organization, i.e. its clusters with their settings, routes and policies, " +
+			"as a single JSON document, e.g. to write nightly backups or diff the configuration between runs with the `local_file` resource. " +
+			"Objects are sorted by ID and exported with all fields returned by the API.",
+		Attributes: map[string]schema.Attribute{
+			"redact_secrets": schema.BoolAttribute{
+				MarkdownDescription: fmt.Sprintf("If set to `true`, the values of secrets, such as the client secret of the identity provider "+
+					"and Kubernetes service account tokens, are replaced by `%s`. Defaults to `false`.", redactedValue),
+				Optional: true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The configuration of the organization as an indented JSON document, with the keys `organizationId`, " +
+					"`clusters`, `settings` (the settings of each cluster, by cluster ID), `routes` and `policies`. " +
+					"Marked as sensitive, as it holds secrets unless `redact_secrets` is set.",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ExportDataSource.
+func (d *ExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read fetches the configuration of the organization and exports it.
+func (d *ExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export := organizationExport{
+		OrganizationID: d.organizationID,
+		Settings:       make(map[string]interface{}),
+	}
+
+	collections := []struct {
+		name string
+		list *[]interface{}
+	}{
+		{"clusters", &export.Clusters},
+		{"routes", &export.Routes},
+		{"policies", &export.Policies},
+	}
+	for _, collection := range collections {
+		url := fmt.Sprintf("%s/organizations/%s/%s", apiBaseURL, d.organizationID, collection.name)
+		if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, collection.list); err != nil {
+			resp.Diagnostics.Append(errorDiagnostics("Error exporting configuration", "Unable to list "+collection.name, err)...)
+			return
+		}
+		sortObjectsByID(*collection.list)
+	}
+
+	for _, cluster := range export.Clusters {
+		clusterID := objectID(cluster)
+		if clusterID == "" {
+			continue
+		}
+
+		var settings interface{}
+		url := fmt.Sprintf("%s/organizations/%s/clusters/%s/settings", apiBaseURL, d.organizationID, clusterID)
+		if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &settings); err != nil {
+			resp.Diagnostics.Append(errorDiagnostics("Error exporting configuration", "Unable to read the settings of cluster "+clusterID, err)...)
+			return
+		}
+		export.Settings[clusterID] = settings
+	}
+
+	var document interface{} = export
+	if data.RedactSecrets.ValueBool() {
+		// Redact a generic copy of the export, so that all objects are handled alike
+		encoded, err := json.Marshal(export)
+		if err == nil {
+			err = json.Unmarshal(encoded, &document)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error exporting configuration", "Unable to redact secrets: "+err.Error())
+			return
+		}
+		document = redactSecrets(document)
+	}
+
+	encoded, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Error exporting configuration", "Unable to encode the export: "+err.Error())
+		return
+	}
+	data.JSON = types.StringValue(string(encoded))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// objectID returns the ID of an object returned by the API, or an empty string when it has none.
+func objectID(object interface{}) string {
+	if fields, ok := object.(map[string]interface{}); ok {
+		if id, ok := fields["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// sortObjectsByID sorts objects returned by the API by their ID, so that exports only differ when the configuration does.
+func sortObjectsByID(objects []interface{}) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objectID(objects[i]) < objectID(objects[j])
+	})
+}
+
+// redactSecrets replaces the non-empty string values of keys holding secrets by redactedValue, at any depth.
+func redactSecrets(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if s, ok := field.(string); ok && s != "" && isSecretKey(key) {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactSecrets(field)
+		}
+	case []interface{}:
+		for i, element := range value {
+			value[i] = redactSecrets(element)
+		}
+	}
+	return value
+}
+
+// isSecretKey reports whether the key of an API object holds a secret.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+)
+
+const (
+	// fixturesEnvVar names the environment variable holding the path of the HTTP fixtures file.
+	fixturesEnvVar = "POMERIUMZERO_HTTP_FIXTURES"
+	// fixturesModeEnvVar names the environment variable selecting whether fixtures are recorded or replayed.
+	fixturesModeEnvVar = "POMERIUMZERO_HTTP_FIXTURES_MODE"
+
+	fixturesModeRecord = "record"
+	fixturesModeReplay = "replay"
+
+	// redacted replaces secrets in recorded fixtures and logged bodies.
+	redacted = "REDACTED"
+)
+
+// sensitiveFieldPattern matches the JSON field names whose values are redacted from recorded fixtures
+// and logged bodies.
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)(token|secret|password|privatekey|clientkey|apikey|jsonkey|^key$)`)
+
+// fixtureInteraction is a recorded request to the Pomerium Zero API and its response.
+type fixtureInteraction struct {
+	Method       string          `json:"method"`
+	URL          string          `json:"url"`
+	RequestBody  json.RawMessage `json:"requestBody,omitempty"`
+	StatusCode   int             `json:"statusCode"`
+	ResponseBody json.RawMessage `json:"responseBody,omitempty"`
+}
+
+// fixtureTransport records API interactions into a fixtures file, or replays them from it without
+// contacting the API. Secrets are redacted when recording, so fixtures can be committed, and requests
+// are matched on their method, URL and redacted body when replaying.
+type fixtureTransport struct {
+	mode         string
+	path         string
+	next         http.RoundTripper
+	mu           sync.Mutex
+	interactions []fixtureInteraction
+	used         []bool
+}
+
+// fixtureTransportFromEnv returns the transport configured by the fixtures environment variables,
+// which sends requests with next when recording, or nil when they are not set, in which case next is used.
+func fixtureTransportFromEnv(next http.RoundTripper) (http.RoundTripper, error) {
+	path := os.Getenv(fixturesEnvVar)
+	if path == "" {
+		return nil, nil
+	}
+
+	mode := os.Getenv(fixturesModeEnvVar)
+	if mode == "" {
+		mode = fixturesModeReplay
+	}
+	return newFixtureTransport(mode, path, next)
+}
+
+// newFixtureTransport creates a fixtureTransport. When replaying, the fixtures are loaded from path.
+func newFixtureTransport(mode, path string, next http.RoundTripper) (*fixtureTransport, error) {
+	t := &fixtureTransport{mode: mode, path: path, next: next}
+
+	switch mode {
+	case fixturesModeRecord:
+		log.Printf("[INFO] Recording HTTP fixtures to %s", path)
+	case fixturesModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading HTTP fixtures: %w", err)
+		}
+		if err := json.Unmarshal(data, &t.interactions); err != nil {
+			return nil, fmt.Errorf("error decoding HTTP fixtures %s: %w", path, err)
+		}
+		// Fixtures are stored indented, while request bodies are matched in their compact form
+		for i, interaction := range t.interactions {
+			if len(interaction.RequestBody) > 0 {
+				var compact bytes.Buffer
+				if err := json.Compact(&compact, interaction.RequestBody); err != nil {
+					return nil, fmt.Errorf("error decoding HTTP fixtures %s: %w", path, err)
+				}
+				t.interactions[i].RequestBody = compact.Bytes()
+			}
+		}
+		t.used = make([]bool, len(t.interactions))
+		log.Printf("[INFO] Replaying %d HTTP fixtures from %s", len(t.interactions), path)
+	default:
+		return nil, fmt.Errorf("%s must be %q or %q, got: %q", fixturesModeEnvVar, fixturesModeRecord, fixturesModeReplay, mode)
+	}
+
+	return t, nil
+}
+
+// RoundTrip records or replays a single request.
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+	redactedRequestBody := redactJSON(requestBody)
+
+	if t.mode == fixturesModeReplay {
+		return t.replay(req, redactedRequestBody)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	if err := t.record(fixtureInteraction{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		RequestBody:  redactedRequestBody,
+		StatusCode:   resp.StatusCode,
+		ResponseBody: redactJSON(responseBody),
+	}); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching the request.
+func (t *fixtureTransport) replay(req *http.Request, requestBody json.RawMessage) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method || interaction.URL != req.URL.String() ||
+			!bytes.Equal(interaction.RequestBody, requestBody) {
+			continue
+		}
+		t.used[i] = true
+
+		return &http.Response{
+			Status:        http.StatusText(interaction.StatusCode),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no HTTP fixture recorded for %s %s", req.Method, req.URL)
+}
+
+// record appends an interaction to the fixtures file. The file is rewritten after each interaction,
+// as the provider has no hook to run when it shuts down.
+func (t *fixtureTransport) record(interaction fixtureInteraction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.interactions = append(t.interactions, interaction)
+
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding HTTP fixtures: %w", err)
+	}
+	if err := os.WriteFile(t.path, data, 0o600); err != nil {
+		return fmt.Errorf("error writing HTTP fixtures: %w", err)
+	}
+	return nil
+}
+
+// redactJSON replaces the values of sensitive fields in a JSON document. Bodies that are not JSON
+// are dropped, as they cannot be checked for secrets.
+func redactJSON(data []byte) json.RawMessage {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return json.RawMessage(`"` + redacted + `"`)
+	}
+
+	result, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return json.RawMessage(`"` + redacted + `"`)
+	}
+	return result
+}
+
+// redactValue replaces the values of sensitive fields in a decoded JSON value.
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if sensitiveFieldPattern.MatchString(key) {
+				if _, ok := field.(string); ok {
+					value[key] = redacted
+					continue
+				}
+			}
+			value[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+	}
+	return value
+}
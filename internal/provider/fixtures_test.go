@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// routeCRUDFixtures holds the interactions of creating, reading, updating and deleting a route.
+const routeCRUDFixtures = "testdata/route_crud.json"
+
+// fixtureRoutePlan returns the plan of the route recorded in routeCRUDFixtures.
+func fixtureRoutePlan() RouteResourceModel {
+	return RouteResourceModel{
+		Name:                          types.StringValue("app"),
+		NamespaceID:                   types.StringValue("ns-0123456789"),
+		From:                          types.StringValue("https://app.example.com"),
+		To:                            types.ListValueMust(types.StringType, []attr.Value{types.StringValue("http://app.internal:8080")}),
+		AllowWebsockets:               types.BoolNull(),
+		PassIdentityHeaders:           types.BoolValue(true),
+		PreserveHostHeader:            types.BoolNull(),
+		PolicyIDs:                     types.ListNull(types.StringType),
+		Prefix:                        types.StringNull(),
+		PrefixRewrite:                 types.StringNull(),
+		KubernetesServiceAccountToken: types.StringValue("sa-token-from-the-plan"),
+		TLSDownstreamServerName:       types.StringNull(),
+		TLSDownstreamClientCA:         types.StringNull(),
+	}
+}
+
+func TestFixtureTransportReplaysRouteCRUD(t *testing.T) {
+	t.Setenv(fixturesEnvVar, routeCRUDFixtures)
+	t.Setenv(fixturesModeEnvVar, "")
+
+	// Replaying must not contact the API
+	transport, err := fixtureTransportFromEnv(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to the API: %s %s", req.Method, req.URL)
+		return nil, nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &RouteResource{client: &http.Client{Transport: transport}, token: "token", organizationID: "org-0123456789"}
+	ctx := context.Background()
+	plan := fixtureRoutePlan()
+
+	created, err := r.createRoute(ctx, &plan)
+	if err != nil {
+		t.Fatalf("create: %s", err)
+	}
+	model, diags := mapRouteResponseToModel(ctx, created)
+	if diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if model.ID.ValueString() != "rt-0123456789" || model.Name.ValueString() != "app" {
+		t.Errorf("create: unexpected route %s %q", model.ID, model.Name.ValueString())
+	}
+
+	read, err := r.readRoute(ctx, "rt-0123456789")
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+	if model, _ := mapRouteResponseToModel(ctx, read); !model.PassIdentityHeaders.ValueBool() {
+		t.Errorf("read: expected pass_identity_headers to be true")
+	}
+
+	plan.ID = types.StringValue("rt-0123456789")
+	plan.Name = types.StringValue("app-renamed")
+	updated, err := r.updateRoute(ctx, &plan)
+	if err != nil {
+		t.Fatalf("update: %s", err)
+	}
+	if model, _ := mapRouteResponseToModel(ctx, updated); model.Name.ValueString() != "app-renamed" {
+		t.Errorf("update: expected the renamed route, got %q", model.Name.ValueString())
+	}
+
+	if err := r.deleteRoute(ctx, "rt-0123456789"); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+
+	// Each interaction is replayed once
+	if err := r.deleteRoute(ctx, "rt-0123456789"); err == nil || !strings.Contains(err.Error(), "no HTTP fixture recorded") {
+		t.Errorf("expected the second delete to miss the fixtures, got: %v", err)
+	}
+}
+
+func TestFixtureTransportReplayMatchesRequestBody(t *testing.T) {
+	transport, err := newFixtureTransport(fixturesModeReplay, routeCRUDFixtures, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &RouteResource{client: &http.Client{Transport: transport}, token: "token", organizationID: "org-0123456789"}
+
+	plan := fixtureRoutePlan()
+	plan.From = types.StringValue("https://other.example.com")
+	if _, err := r.createRoute(context.Background(), &plan); err == nil || !strings.Contains(err.Error(), "no HTTP fixture recorded") {
+		t.Errorf("expected a route differing from the fixtures to miss them, got: %v", err)
+	}
+
+	// Secrets are redacted before matching, so replays do not depend on their values
+	plan = fixtureRoutePlan()
+	plan.KubernetesServiceAccountToken = types.StringValue("another-sa-token")
+	if _, err := r.createRoute(context.Background(), &plan); err != nil {
+		t.Errorf("expected the route to match the fixtures regardless of its secrets, got: %s", err)
+	}
+}
+
+func TestFixtureTransportRecordsRedactedInteractions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"id":"tok-1","name":"ci","token":"secret-api-token","scopes":["read"]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	t.Setenv(fixturesEnvVar, path)
+	t.Setenv(fixturesModeEnvVar, fixturesModeRecord)
+	transport, err := fixtureTransportFromEnv(http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v0/organizations/org-1/apiTokens", strings.NewReader(`{"name":"ci","clientSecret":"secret-client-secret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret-bearer-token")
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The provider still receives the secrets of the response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "secret-api-token") {
+		t.Errorf("expected the response to be passed on unredacted, got %s", body)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-api-token", "secret-client-secret", "secret-bearer-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be redacted from the fixtures, got:\n%s", secret, data)
+		}
+	}
+
+	var interactions []fixtureInteraction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		t.Fatal(err)
+	}
+	if len(interactions) != 1 {
+		t.Fatalf("expected 1 recorded interaction, got %d", len(interactions))
+	}
+	if got, want := compactJSON(t, interactions[0].ResponseBody), `{"id":"tok-1","name":"ci","scopes":["read"],"token":"REDACTED"}`; got != want {
+		t.Errorf("expected the response body %s, got %s", want, got)
+	}
+	if got, want := compactJSON(t, interactions[0].RequestBody), `{"clientSecret":"REDACTED","name":"ci"}`; got != want {
+		t.Errorf("expected the request body %s, got %s", want, got)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "empty body",
+			input: "",
+			want:  "",
+		},
+		{
+			name:  "no secrets",
+			input: `{"name":"app","keyId":"k-1"}`,
+			want:  `{"keyId":"k-1","name":"app"}`,
+		},
+		{
+			name:  "sensitive fields in any case",
+			input: `{"Token":"a","sharedSecret":"b","PASSWORD":"c","privateKey":"d","clientKey":"e","key":"f"}`,
+			want:  `{"PASSWORD":"REDACTED","Token":"REDACTED","clientKey":"REDACTED","key":"REDACTED","privateKey":"REDACTED","sharedSecret":"REDACTED"}`,
+		},
+		{
+			name:  "credentials of directory providers",
+			input: `{"clientSecret":"a","jsonKey":"b","apiKey":"c"}`,
+			want:  `{"apiKey":"REDACTED","clientSecret":"REDACTED","jsonKey":"REDACTED"}`,
+		},
+		{
+			name:  "nested objects and arrays",
+			input: `{"settings":{"idp":{"clientSecret":"a"}},"tokens":[{"token":"b"}]}`,
+			want:  `{"settings":{"idp":{"clientSecret":"REDACTED"}},"tokens":[{"token":"REDACTED"}]}`,
+		},
+		{
+			name:  "sensitive field without a string value",
+			input: `{"tokenCount":3,"secret":null}`,
+			want:  `{"secret":null,"tokenCount":3}`,
+		},
+		{
+			name:  "body that is not JSON",
+			input: "token=secret",
+			want:  `"REDACTED"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(redactJSON([]byte(tt.input))); got != tt.want {
+				t.Errorf("redactJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixtureTransportFromEnv(t *testing.T) {
+	t.Setenv(fixturesEnvVar, "")
+	if transport, err := fixtureTransportFromEnv(http.DefaultTransport); transport != nil || err != nil {
+		t.Errorf("expected no transport without fixtures, got %v, %v", transport, err)
+	}
+
+	t.Setenv(fixturesEnvVar, routeCRUDFixtures)
+	t.Setenv(fixturesModeEnvVar, "playback")
+	if _, err := fixtureTransportFromEnv(http.DefaultTransport); err == nil || !strings.Contains(err.Error(), fixturesModeEnvVar) {
+		t.Errorf("expected an invalid mode to be rejected, got: %v", err)
+	}
+
+	t.Setenv(fixturesEnvVar, filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv(fixturesModeEnvVar, fixturesModeReplay)
+	if _, err := fixtureTransportFromEnv(http.DefaultTransport); err == nil {
+		t.Error("expected missing fixtures to be reported when replaying")
+	}
+}
+
+// compactJSON returns the JSON document without insignificant space, as recorded fixtures are indented.
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		t.Fatal(err)
+	}
+	return compact.String()
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
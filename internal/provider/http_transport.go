@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRequestTimeout is how long a single attempt of a request may take by default.
+	defaultRequestTimeout = 10 * time.Second
+	// defaultIdleConnTimeout is how long idle connections to the API are kept open by default.
+	defaultIdleConnTimeout = 90 * time.Second
+	// maxIdleConnsPerHost is the default number of idle connections kept open to the API. It exceeds the
+	// default parallelism of Terraform, so that concurrent operations of large plans reuse connections
+	// instead of opening and closing one per request, as they do with the two of http.DefaultTransport.
+	maxIdleConnsPerHost = 32
+	// maxPooledBufferSize is the capacity above which buffers are not returned to bufferPool, so that
+	// a single large response does not keep its memory allocated for the rest of the operation.
+	maxPooledBufferSize = 4 << 20
+)
+
+// httpTransportSettings are the connection settings of the provider configuration.
+type httpTransportSettings struct {
+	// idleConnTimeout is how long idle connections are kept open for reuse, or zero to close
+	// connections after each request
+	idleConnTimeout time.Duration
+	// maxIdleConns is the number of idle connections kept open
+	maxIdleConns int
+	// rootCAs are the certificate authorities trusted to sign the certificate of the API, or nil for
+	// those of the system
+	rootCAs *x509.CertPool
+}
+
+// defaultHTTPTransportSettings are the connection settings when the provider configuration leaves them unset.
+var defaultHTTPTransportSettings = httpTransportSettings{
+	idleConnTimeout: defaultIdleConnTimeout,
+	maxIdleConns:    maxIdleConnsPerHost,
+}
+
+// sharedTransport sends the requests of all provider instances of the process with the default
+// connection settings, e.g. of provider aliases, so that they share its pool of connections to the API.
+var sharedTransport http.RoundTripper = newHTTPTransport(defaultHTTPTransportSettings)
+
+// newHTTPTransport creates a transport with the connection settings.
+func newHTTPTransport(settings httpTransportSettings) *http.Transport {
+	var tlsConfig *tls.Config
+	if settings.rootCAs != nil {
+		tlsConfig = &tls.Config{RootCAs: settings.rootCAs, MinVersion: tls.VersionTLS12}
+	}
+
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		DisableKeepAlives:     settings.idleConnTimeout == 0,
+		MaxIdleConns:          settings.maxIdleConns,
+		MaxIdleConnsPerHost:   settings.maxIdleConns,
+		IdleConnTimeout:       settings.idleConnTimeout,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// httpTransport returns sharedTransport for the default connection settings, and a transport of
+// its own for others.
+func httpTransport(settings httpTransportSettings) http.RoundTripper {
+	if settings == defaultHTTPTransportSettings {
+		return sharedTransport
+	}
+	return newHTTPTransport(settings)
+}
+
+// caCertPool returns the certificate authorities of the system, and those of a bundle of PEM encoded
+// certificates, e.g. of a TLS-intercepting proxy.
+func caCertPool(bundle []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return pool, nil
+}
+
+// bufferPool holds the buffers that response bodies are read into, so that reading the large lists of
+// big organizations does not allocate and grow a new buffer for every request.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from bufferPool.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to bufferPool. The buffer and the slices returned by its Bytes method
+// must not be used afterwards.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// idempotencyKeyHeader is the header carrying the idempotency key of a create request. The API
+// creates at most one object per key, so a retried request returns the object created by the first.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyContextKey is the context key of the idempotency key of a create operation.
+type idempotencyKeyContextKey struct{}
+
+// withIdempotencyKey returns a context carrying a new idempotency key. All POST requests made with
+// the context send the same key, so that retrying them never creates duplicate objects. Create
+// operations call it once, before their first request.
+func withIdempotencyKey(ctx context.Context) context.Context {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		// Without a key, requests are sent as before
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, hex.EncodeToString(key))
+}
+
+// idempotencyKeyTransport sets the idempotency key header on POST requests whose context carries a key.
+type idempotencyKeyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sends the request with the idempotency key of its context, if any.
+func (t *idempotencyKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key, ok := req.Context().Value(idempotencyKeyContextKey{}).(string)
+	if !ok || req.Method != http.MethodPost || req.Header.Get(idempotencyKeyHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.Header.Set(idempotencyKeyHeader, key)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IdentityProvidersDataSource{}
+
+// NewIdentityProvidersDataSource creates a new IdentityProvidersDataSource.
+func NewIdentityProvidersDataSource() datasource.DataSource {
+	return &IdentityProvidersDataSource{}
+}
+
+// IdentityProvidersDataSource defines the data source implementation.
+type IdentityProvidersDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// IdentityProvidersDataSourceModel describes the data source data model.
+type IdentityProvidersDataSourceModel struct {
+	IdentityProviders []IdentityProviderTypeDataModel `tfsdk:"identity_providers"`
+}
+
+// IdentityProviderTypeDataModel describes a single identity provider type returned by the IdentityProvidersDataSource.
+type IdentityProviderTypeDataModel struct {
+	Type           types.String   `tfsdk:"type"`
+	DisplayName    types.String   `tfsdk:"display_name"`
+	RequiredFields []types.String `tfsdk:"required_fields"`
+	OptionalFields []types.String `tfsdk:"optional_fields"`
+}
+
+// Metadata sets the data source type name for the IdentityProvidersDataSource.
+func (d *IdentityProvidersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_identity_providers"
+}
+
+// Schema defines the structure and attributes of the IdentityProvidersDataSource.
+func (d *IdentityProvidersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the identity provider types supported by Pomerium Zero with their configuration fields, " +
+			"e.g. to validate the `identity_provider` settings of `pomeriumzero_cluster_settings` before applying them.",
+		Attributes: map[string]schema.Attribute{
+			"identity_providers": schema.ListNestedAttribute{
+				MarkdownDescription: "The supported identity provider types.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the identity provider, as used in the `identity_provider` cluster setting, e.g. `oidc`.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "The human-readable name of the identity provider.",
+							Computed:            true,
+						},
+						"required_fields": schema.ListAttribute{
+							MarkdownDescription: "The configuration fields that must be set for the identity provider, e.g. `identity_provider_url`.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"optional_fields": schema.ListAttribute{
+							MarkdownDescription: "The configuration fields that may be set for the identity provider.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the IdentityProvidersDataSource.
+func (d *IdentityProvidersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the supported identity provider types.
+func (d *IdentityProvidersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	url := fmt.Sprintf("%s/organizations/%s/identity-providers", apiBaseURL, d.organizationID)
+
+	var identityProviders []IdentityProviderType
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &identityProviders); err != nil {
+		resp.Diagnostics.AddError("Error fetching identity providers", err.Error())
+		return
+	}
+
+	data := IdentityProvidersDataSourceModel{IdentityProviders: []IdentityProviderTypeDataModel{}}
+	for _, identityProvider := range identityProviders {
+		data.IdentityProviders = append(data.IdentityProviders, IdentityProviderTypeDataModel{
+			Type:           types.StringValue(identityProvider.Type),
+			DisplayName:    types.StringValue(identityProvider.DisplayName),
+			RequiredFields: stringValues(identityProvider.RequiredFields),
+			OptionalFields: stringValues(identityProvider.OptionalFields),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// stringValues converts a slice of strings to a slice of Terraform string values.
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
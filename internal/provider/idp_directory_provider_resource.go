@@ -0,0 +1,391 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IdpDirectoryProviderResource{}
+var _ resource.ResourceWithImportState = &IdpDirectoryProviderResource{}
+var _ resource.ResourceWithValidateConfig = &IdpDirectoryProviderResource{}
+
+// NewIdpDirectoryProviderResource creates a new IdpDirectoryProviderResource.
+func NewIdpDirectoryProviderResource() resource.Resource {
+	return &IdpDirectoryProviderResource{}
+}
+
+// IdpDirectoryProviderResource defines the resource implementation.
+type IdpDirectoryProviderResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// IdpDirectoryProviderResourceModel describes the resource data model.
+type IdpDirectoryProviderResourceModel struct {
+	ID              types.String                      `tfsdk:"id"`
+	ClusterID       types.String                      `tfsdk:"cluster_id"`
+	RefreshInterval durationValue                     `tfsdk:"refresh_interval"`
+	RefreshTimeout  durationValue                     `tfsdk:"refresh_timeout"`
+	Azure           *IdpDirectoryAzureModel           `tfsdk:"azure"`
+	GoogleWorkspace *IdpDirectoryGoogleWorkspaceModel `tfsdk:"google_workspace"`
+	Okta            *IdpDirectoryOktaModel            `tfsdk:"okta"`
+	LastSyncedAt    types.String                      `tfsdk:"last_synced_at"`
+}
+
+// IdpDirectoryAzureModel describes the Azure AD directory sync options.
+type IdpDirectoryAzureModel struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	DirectoryID  types.String `tfsdk:"directory_id"`
+}
+
+// IdpDirectoryGoogleWorkspaceModel describes the Google Workspace directory sync options.
+type IdpDirectoryGoogleWorkspaceModel struct {
+	JSONKey         types.String `tfsdk:"json_key"`
+	ImpersonateUser types.String `tfsdk:"impersonate_user"`
+}
+
+// IdpDirectoryOktaModel describes the Okta directory sync options.
+type IdpDirectoryOktaModel struct {
+	URL      types.String `tfsdk:"url"`
+	APIToken types.String `tfsdk:"api_token"`
+}
+
+// Metadata sets the resource type name for the IdpDirectoryProviderResource.
+func (r *IdpDirectoryProviderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_idp_directory_provider"
+}
+
+// Schema defines the structure and attributes of the IdpDirectoryProviderResource.
+func (r *IdpDirectoryProviderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the directory provider of a Pomerium Zero cluster. Directory sync imports users and groups from the identity provider, " +
+			"so policies can match on group membership. Exactly one of `azure`, `google_workspace` or `okta` must be configured.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the directory provider. This corresponds to the cluster ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to configure directory sync for.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"refresh_interval": schema.StringAttribute{
+				CustomType:          durationType{},
+				MarkdownDescription: "How often users and groups are synced from the directory, e.g. `10m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"refresh_timeout": schema.StringAttribute{
+				CustomType:          durationType{},
+				MarkdownDescription: "The maximum duration of a single directory sync, e.g. `1m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"azure": schema.SingleNestedAttribute{
+				MarkdownDescription: "Sync users and groups from Azure Active Directory (Microsoft Entra ID).",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "The client ID of the Azure application registration.",
+						Required:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "The client secret of the Azure application registration.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"directory_id": schema.StringAttribute{
+						MarkdownDescription: "The ID of the Azure directory (tenant).",
+						Required:            true,
+					},
+				},
+			},
+			"google_workspace": schema.SingleNestedAttribute{
+				MarkdownDescription: "Sync users and groups from Google Workspace.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"json_key": schema.StringAttribute{
+						MarkdownDescription: "The JSON key of the service account used to query the directory.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"impersonate_user": schema.StringAttribute{
+						MarkdownDescription: "The email of a Google Workspace administrator impersonated by the service account.",
+						Optional:            true,
+					},
+				},
+			},
+			"okta": schema.SingleNestedAttribute{
+				MarkdownDescription: "Sync users and groups from Okta.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the Okta organization, e.g. `https://example.okta.com`.",
+						Required:            true,
+					},
+					"api_token": schema.StringAttribute{
+						MarkdownDescription: "The Okta API token used to query the directory.",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"last_synced_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp of the last successful directory sync.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that exactly one directory type is configured.
+func (r *IdpDirectoryProviderResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data IdpDirectoryProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured := 0
+	for _, set := range []bool{data.Azure != nil, data.GoogleWorkspace != nil, data.Okta != nil} {
+		if set {
+			configured++
+		}
+	}
+
+	if configured != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid Directory Provider Configuration",
+			"Exactly one of azure, google_workspace or okta must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the IdpDirectoryProviderResource.
+func (r *IdpDirectoryProviderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create configures the directory provider of a cluster.
+func (r *IdpDirectoryProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdpDirectoryProviderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	directory, err := r.putDirectoryProvider(ctx, plan.ClusterID.ValueString(), createDirectoryProviderRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating directory provider", err.Error())
+		return
+	}
+
+	plan.ID = plan.ClusterID
+	updateIdpDirectoryProviderResourceModel(&plan, directory)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the directory provider of a cluster.
+func (r *IdpDirectoryProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdpDirectoryProviderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	directory, err := r.getDirectoryProvider(ctx, state.ClusterID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading directory provider", err.Error())
+		return
+	}
+
+	updateIdpDirectoryProviderResourceModel(&state, directory)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the directory provider of a cluster.
+func (r *IdpDirectoryProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IdpDirectoryProviderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	directory, err := r.putDirectoryProvider(ctx, plan.ClusterID.ValueString(), createDirectoryProviderRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating directory provider", err.Error())
+		return
+	}
+
+	updateIdpDirectoryProviderResourceModel(&plan, directory)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete disables directory sync for the cluster.
+func (r *IdpDirectoryProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IdpDirectoryProviderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/directory-provider", apiBaseURL, r.organizationID, state.ClusterID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting directory provider", err.Error())
+		return
+	}
+}
+
+// ImportState imports the directory provider of a cluster by the cluster ID.
+// Secrets are never returned by the API, so they have to be set in the configuration after importing.
+func (r *IdpDirectoryProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	directory, err := r.getDirectoryProvider(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing directory provider", fmt.Sprintf("Unable to read directory provider for %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := IdpDirectoryProviderResourceModel{
+		ID:        types.StringValue(req.ID),
+		ClusterID: types.StringValue(req.ID),
+	}
+	updateIdpDirectoryProviderResourceModel(&state, directory)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getDirectoryProvider fetches the directory provider of a cluster.
+func (r *IdpDirectoryProviderResource) getDirectoryProvider(ctx context.Context, clusterID string) (*DirectoryProvider, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/directory-provider", apiBaseURL, r.organizationID, clusterID)
+
+	var directory DirectoryProvider
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &directory); err != nil {
+		return nil, err
+	}
+
+	return &directory, nil
+}
+
+// putDirectoryProvider creates or replaces the directory provider of a cluster.
+func (r *IdpDirectoryProviderResource) putDirectoryProvider(ctx context.Context, clusterID string, directory DirectoryProvider) (*DirectoryProvider, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/directory-provider", apiBaseURL, r.organizationID, clusterID)
+
+	var updated DirectoryProvider
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, directory, http.StatusOK, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// createDirectoryProviderRequest creates the API request body from the IdpDirectoryProviderResourceModel.
+func createDirectoryProviderRequest(model IdpDirectoryProviderResourceModel) DirectoryProvider {
+	directory := DirectoryProvider{
+		RefreshInterval: model.RefreshInterval.ValueString(),
+		RefreshTimeout:  model.RefreshTimeout.ValueString(),
+		Options:         map[string]string{},
+	}
+
+	switch {
+	case model.Azure != nil:
+		directory.Provider = "azure"
+		directory.Options["clientId"] = model.Azure.ClientID.ValueString()
+		directory.Options["clientSecret"] = model.Azure.ClientSecret.ValueString()
+		directory.Options["directoryId"] = model.Azure.DirectoryID.ValueString()
+	case model.GoogleWorkspace != nil:
+		directory.Provider = "google"
+		directory.Options["jsonKey"] = model.GoogleWorkspace.JSONKey.ValueString()
+		if !model.GoogleWorkspace.ImpersonateUser.IsNull() {
+			directory.Options["impersonateUser"] = model.GoogleWorkspace.ImpersonateUser.ValueString()
+		}
+	case model.Okta != nil:
+		directory.Provider = "okta"
+		directory.Options["url"] = model.Okta.URL.ValueString()
+		directory.Options["apiKey"] = model.Okta.APIToken.ValueString()
+	}
+
+	return directory
+}
+
+// updateIdpDirectoryProviderResourceModel updates the model with the directory provider returned by the API.
+// Secrets are not returned by the API, so the values already in the model are kept.
+func updateIdpDirectoryProviderResourceModel(model *IdpDirectoryProviderResourceModel, directory *DirectoryProvider) {
+	model.RefreshInterval = durationValueOrNull(directory.RefreshInterval)
+	model.RefreshTimeout = durationValueOrNull(directory.RefreshTimeout)
+	model.LastSyncedAt = stringValueOrNull(directory.LastSyncedAt)
+
+	switch directory.Provider {
+	case "azure":
+		azure := &IdpDirectoryAzureModel{ClientSecret: types.StringNull()}
+		if model.Azure != nil {
+			azure.ClientSecret = model.Azure.ClientSecret
+		}
+		azure.ClientID = types.StringValue(directory.Options["clientId"])
+		azure.DirectoryID = types.StringValue(directory.Options["directoryId"])
+		model.Azure, model.GoogleWorkspace, model.Okta = azure, nil, nil
+	case "google":
+		google := &IdpDirectoryGoogleWorkspaceModel{JSONKey: types.StringNull()}
+		if model.GoogleWorkspace != nil {
+			google.JSONKey = model.GoogleWorkspace.JSONKey
+		}
+		google.ImpersonateUser = stringValueOrNull(directory.Options["impersonateUser"])
+		model.Azure, model.GoogleWorkspace, model.Okta = nil, google, nil
+	case "okta":
+		okta := &IdpDirectoryOktaModel{APIToken: types.StringNull()}
+		if model.Okta != nil {
+			okta.APIToken = model.Okta.APIToken
+		}
+		okta.URL = types.StringValue(directory.Options["url"])
+		model.Azure, model.GoogleWorkspace, model.Okta = nil, nil, okta
+	}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &JWKSDataSource{}
+
+// NewJWKSDataSource creates a new JWKSDataSource.
+func NewJWKSDataSource() datasource.DataSource {
+	return &JWKSDataSource{}
+}
+
+// JWKSDataSource defines the data source implementation.
+type JWKSDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// JWKSDataSourceModel describes the data source data model.
+type JWKSDataSourceModel struct {
+	ClusterID types.String          `tfsdk:"cluster_id"`
+	JWKSURL   types.String          `tfsdk:"jwks_url"`
+	JWKS      types.String          `tfsdk:"jwks"`
+	Keys      []JSONWebKeyDataModel `tfsdk:"keys"`
+}
+
+// JSONWebKeyDataModel describes a single key returned by the JWKSDataSource.
+type JSONWebKeyDataModel struct {
+	KeyID     types.String `tfsdk:"kid"`
+	KeyType   types.String `tfsdk:"kty"`
+	Algorithm types.String `tfsdk:"alg"`
+	Use       types.String `tfsdk:"use"`
+}
+
+// Metadata sets the data source type name for the JWKSDataSource.
+func (d *JWKSDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_jwks"
+}
+
+// Schema defines the structure and attributes of the JWKSDataSource.
+func (d *JWKSDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exposes the keys a cluster signs its assertion JWTs with, so upstream applications can be configured to verify " +
+			"the `X-Pomerium-Jwt-Assertion` header.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster.",
+				Required:            true,
+			},
+			"jwks_url": schema.StringAttribute{
+				MarkdownDescription: "The URL the JSON Web Key Set of the cluster is served at.",
+				Computed:            true,
+			},
+			"jwks": schema.StringAttribute{
+				MarkdownDescription: "The JSON Web Key Set of the cluster, as JSON.",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The keys in the JSON Web Key Set.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kid": schema.StringAttribute{
+							MarkdownDescription: "The ID of the key.",
+							Computed:            true,
+						},
+						"kty": schema.StringAttribute{
+							MarkdownDescription: "The type of the key, e.g. `EC`.",
+							Computed:            true,
+						},
+						"alg": schema.StringAttribute{
+							MarkdownDescription: "The signing algorithm of the key, e.g. `ES256`.",
+							Computed:            true,
+						},
+						"use": schema.StringAttribute{
+							MarkdownDescription: "The intended use of the key, e.g. `sig`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the JWKSDataSource.
+func (d *JWKSDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read retrieves the JSON Web Key Set of the cluster.
+func (d *JWKSDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data JWKSDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterURL := fmt.Sprintf("%s/organizations/%s/clusters/%s", apiBaseURL, d.organizationID, data.ClusterID.ValueString())
+
+	var cluster Cluster
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", clusterURL, nil, http.StatusOK, &cluster); err != nil {
+		resp.Diagnostics.AddError("Error fetching cluster", err.Error())
+		return
+	}
+
+	var jwks json.RawMessage
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", clusterURL+"/jwks", nil, http.StatusOK, &jwks); err != nil {
+		resp.Diagnostics.AddError("Error fetching JWKS", err.Error())
+		return
+	}
+
+	var keySet struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(jwks, &keySet); err != nil {
+		resp.Diagnostics.AddError("Error parsing JWKS", err.Error())
+		return
+	}
+
+	data.JWKSURL = types.StringValue(fmt.Sprintf("https://%s/.well-known/pomerium/jwks.json", cluster.FQDN))
+	data.JWKS = types.StringValue(string(jwks))
+	data.Keys = []JSONWebKeyDataModel{}
+	for _, key := range keySet.Keys {
+		data.Keys = append(data.Keys, JSONWebKeyDataModel{
+			KeyID:     types.StringValue(key.KeyID),
+			KeyType:   types.StringValue(key.KeyType),
+			Algorithm: stringValueOrNull(key.Algorithm),
+			Use:       stringValueOrNull(key.Use),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
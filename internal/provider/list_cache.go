@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// cachedCollections lists the collection endpoints whose GET responses are cached, so that reading
+// many routes or policies in a single plan lists them once instead of issuing a GET per object.
+var cachedCollections = []string{"routes", "policies", "clusters", "namespaces"}
+
+// listCacheTransport caches successful GET responses of collection endpoints for the lifetime of the
+// provider, which Terraform runs for a single operation. Any other request clears the cache, so that
+// objects created, updated or deleted by the operation are never read from a stale list.
+type listCacheTransport struct {
+	next    http.RoundTripper
+	mu      sync.Mutex
+	entries map[string]*listCacheEntry
+}
+
+// listCacheEntry is a cached response. Concurrent reads of the same list wait for the first one
+// to complete through done, instead of all fetching the list.
+type listCacheEntry struct {
+	done       chan struct{}
+	statusCode int
+	header     http.Header
+	body       []byte
+	err        error
+}
+
+// newListCacheTransport creates a listCacheTransport.
+func newListCacheTransport(next http.RoundTripper) *listCacheTransport {
+	return &listCacheTransport{next: next, entries: map[string]*listCacheEntry{}}
+}
+
+// RoundTrip serves GET requests of collections from the cache, and clears the cache on other requests.
+// GET requests with a Cache-Control: no-cache header are always sent to the API.
+func (t *listCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.clear(req)
+		resp, err := t.next.RoundTrip(req)
+		// Lists read while the request was in flight may have been read before it was processed
+		t.clear(req)
+		return resp, err
+	}
+	// Requests polling for changes bypass the cache
+	if !isCachedCollection(req.URL.Path) || req.Header.Get("Cache-Control") == "no-cache" {
+		return t.next.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	t.mu.Lock()
+	entry, ok := t.entries[key]
+	if !ok {
+		entry = &listCacheEntry{done: make(chan struct{})}
+		t.entries[key] = entry
+	}
+	t.mu.Unlock()
+
+	if ok {
+		select {
+		case <-entry.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if entry.err == nil && entry.statusCode == http.StatusOK {
+			log.Printf("[DEBUG] Serving GET %s from the list cache", key)
+			return entry.response(req), nil
+		}
+		// The first read failed, so this one is sent on its own
+		return t.next.RoundTrip(req)
+	}
+
+	defer close(entry.done)
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		entry.err = err
+		t.forget(key, entry)
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		entry.statusCode = resp.StatusCode
+		t.forget(key, entry)
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		entry.err = err
+		t.forget(key, entry)
+		return nil, err
+	}
+	entry.statusCode = resp.StatusCode
+	entry.header = resp.Header.Clone()
+	entry.body = body
+
+	return entry.response(req), nil
+}
+
+// clear empties the cache, as the request may modify the objects of any list.
+func (t *listCacheTransport) clear(req *http.Request) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.entries) > 0 {
+		log.Printf("[DEBUG] Clearing %d cached lists after %s %s", len(t.entries), req.Method, req.URL.Path)
+		t.entries = map[string]*listCacheEntry{}
+	}
+}
+
+// forget removes a failed entry from the cache, unless the cache was cleared in the meantime.
+func (t *listCacheTransport) forget(key string, entry *listCacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries[key] == entry {
+		delete(t.entries, key)
+	}
+}
+
+// response returns a new response holding the cached body.
+func (e *listCacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.statusCode),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// isCachedCollection reports whether the path is that of a cached collection endpoint.
+func isCachedCollection(path string) bool {
+	for _, collection := range cachedCollections {
+		if strings.HasSuffix(path, "/"+collection) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &LogExportResource{}
+var _ resource.ResourceWithImportState = &LogExportResource{}
+
+var (
+	// logExportFormats lists the formats logs can be shipped in.
+	logExportFormats = []string{"json", "cef", "syslog"}
+
+	// logExportTypes lists the types of logs that can be shipped.
+	logExportTypes = []string{"access", "authorize", "authenticate", "system"}
+)
+
+// NewLogExportResource creates a new LogExportResource.
+func NewLogExportResource() resource.Resource {
+	return &LogExportResource{}
+}
+
+// LogExportResource defines the resource implementation.
+type LogExportResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// LogExportResourceModel describes the resource data model.
+type LogExportResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ClusterID types.String `tfsdk:"cluster_id"`
+	Name      types.String `tfsdk:"name"`
+	URL       types.String `tfsdk:"url"`
+	Format    types.String `tfsdk:"format"`
+	LogTypes  types.Set    `tfsdk:"log_types"`
+	Headers   types.Map    `tfsdk:"headers"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata sets the resource type name for the LogExportResource.
+func (r *LogExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_export"
+}
+
+// Schema defines the structure and attributes of the LogExportResource.
+func (r *LogExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a log drain shipping the logs of a Pomerium Zero cluster to an external destination, e.g. a SIEM.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the log export.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to ship logs from.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the log export.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The destination URL the logs are posted to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringMatches(webhookURLPattern, "value must be an http or https URL"),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The format the logs are shipped in. One of `json`, `cef` or `syslog`. Defaults to `json`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("json"),
+				Validators: []validator.String{
+					stringOneOf(logExportFormats...),
+				},
+			},
+			"log_types": schema.SetAttribute{
+				MarkdownDescription: "The types of logs to ship. Valid values are `access`, `authorize`, `authenticate` and `system`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setElementsOneOf(logExportTypes...),
+				},
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "HTTP headers sent along with the logs, e.g. to authenticate to the destination. " +
+					"Header values are not returned by the API, so changes made outside of Terraform are not detected.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether logs are shipped to the destination. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the LogExportResource.
+func (r *LogExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create creates the log export.
+func (r *LogExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan LogExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exportReq, diags := createLogExportRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/log-exports", apiBaseURL, r.organizationID, plan.ClusterID.ValueString())
+
+	var export LogExport
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, exportReq, http.StatusCreated, &export); err != nil {
+		resp.Diagnostics.AddError("Error creating log export", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateLogExportResourceModel(ctx, &plan, &export)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the log export.
+func (r *LogExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state LogExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := r.getLogExport(ctx, state.ClusterID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading log export", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateLogExportResourceModel(ctx, &state, export)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the log export.
+func (r *LogExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan LogExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exportReq, diags := createLogExportRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/log-exports/%s", apiBaseURL, r.organizationID, plan.ClusterID.ValueString(), plan.ID.ValueString())
+
+	var export LogExport
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, exportReq, http.StatusOK, &export); err != nil {
+		resp.Diagnostics.AddError("Error updating log export", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateLogExportResourceModel(ctx, &plan, &export)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the log export.
+func (r *LogExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state LogExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/log-exports/%s", apiBaseURL, r.organizationID, state.ClusterID.ValueString(), state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting log export", err.Error())
+		return
+	}
+}
+
+// ImportState imports a log export by the cluster ID and the log export ID, separated by a slash.
+// Header values are never returned by the API, so they have to be set in the configuration after importing.
+func (r *LogExportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	clusterID, exportID, ok := strings.Cut(req.ID, "/")
+	if !ok || clusterID == "" || exportID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <cluster_id>/<log_export_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	export, err := r.getLogExport(ctx, clusterID, exportID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing log export", fmt.Sprintf("Unable to read log export %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := LogExportResourceModel{
+		ClusterID: types.StringValue(clusterID),
+		Headers:   types.MapNull(types.StringType),
+	}
+	resp.Diagnostics.Append(updateLogExportResourceModel(ctx, &state, export)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getLogExport fetches a log export by its ID.
+func (r *LogExportResource) getLogExport(ctx context.Context, clusterID, exportID string) (*LogExport, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/log-exports/%s", apiBaseURL, r.organizationID, clusterID, exportID)
+
+	var export LogExport
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &export); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// createLogExportRequest creates the API request body from the LogExportResourceModel.
+func createLogExportRequest(ctx context.Context, model LogExportResourceModel) (LogExport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	export := LogExport{
+		Name:    model.Name.ValueString(),
+		URL:     model.URL.ValueString(),
+		Format:  model.Format.ValueString(),
+		Enabled: model.Enabled.ValueBool(),
+	}
+
+	diags.Append(model.LogTypes.ElementsAs(ctx, &export.LogTypes, false)...)
+	if !model.Headers.IsNull() {
+		diags.Append(model.Headers.ElementsAs(ctx, &export.Headers, false)...)
+	}
+
+	return export, diags
+}
+
+// updateLogExportResourceModel updates the model with the log export returned by the API.
+// Header values are not returned by the API, so the headers already in the model are kept.
+func updateLogExportResourceModel(ctx context.Context, model *LogExportResourceModel, export *LogExport) diag.Diagnostics {
+	model.ID = types.StringValue(export.ID)
+	model.Name = types.StringValue(export.Name)
+	model.URL = types.StringValue(export.URL)
+	model.Format = types.StringValue(export.Format)
+	model.Enabled = types.BoolValue(export.Enabled)
+
+	logTypes, diags := types.SetValueFrom(ctx, types.StringType, export.LogTypes)
+	model.LogTypes = logTypes
+	return diags
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MembersDataSource{}
+
+// NewMembersDataSource creates a new MembersDataSource.
+func NewMembersDataSource() datasource.DataSource {
+	return &MembersDataSource{}
+}
+
+// MembersDataSource defines the data source implementation.
+type MembersDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// MembersDataSourceModel describes the data source data model.
+type MembersDataSourceModel struct {
+	Role    types.String      `tfsdk:"role"`
+	Members []MemberDataModel `tfsdk:"members"`
+}
+
+// MemberDataModel describes a single member returned by the MembersDataSource.
+type MemberDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Email       types.String `tfsdk:"email"`
+	Name        types.String `tfsdk:"name"`
+	Role        types.String `tfsdk:"role"`
+	LastLoginAt types.String `tfsdk:"last_login_at"`
+}
+
+// Metadata sets the data source type name for the MembersDataSource.
+func (d *MembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_members"
+}
+
+// Schema defines the structure and attributes of the MembersDataSource.
+func (d *MembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the members of the organization with their role and last login, e.g. for access reviews.",
+		Attributes: map[string]schema.Attribute{
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Only list the members with this role. One of `admin`, `editor` or `viewer`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(roles...),
+				},
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching members.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The user ID of the member.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the member.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the member.",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The role of the member in the organization.",
+							Computed:            true,
+						},
+						"last_login_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp at which the member last signed in to the console. Not set for members who never signed in.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the MembersDataSource.
+func (d *MembersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the members matching the role filter.
+func (d *MembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MembersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/members", apiBaseURL, d.organizationID)
+
+	var members []Member
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &members); err != nil {
+		resp.Diagnostics.AddError("Error fetching members", err.Error())
+		return
+	}
+
+	data.Members = []MemberDataModel{}
+	for _, member := range members {
+		if !data.Role.IsNull() && member.Role != data.Role.ValueString() {
+			continue
+		}
+		data.Members = append(data.Members, MemberDataModel{
+			ID:          types.StringValue(member.ID),
+			Email:       types.StringValue(member.Email),
+			Name:        stringValueOrNull(member.Name),
+			Role:        types.StringValue(member.Role),
+			LastLoginAt: stringValueOrNull(member.LastLoginAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
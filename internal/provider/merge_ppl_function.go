@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MergePPLFunction{}
+
+// NewMergePPLFunction creates a new MergePPLFunction.
+func NewMergePPLFunction() function.Function {
+	return &MergePPLFunction{}
+}
+
+// MergePPLFunction combines multiple JSON PPL documents into one.
+type MergePPLFunction struct{}
+
+// Metadata sets the name of the MergePPLFunction.
+func (f *MergePPLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_ppl"
+}
+
+// Definition defines the parameters and return type of the MergePPLFunction.
+func (f *MergePPLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Combines multiple PPL documents into one",
+		MarkdownDescription: "Combines multiple Pomerium Policy Language (PPL) documents written in JSON into a single " +
+			"canonical document for the `ppl` attribute of `pomeriumzero_policy`. The merged document keeps the semantics " +
+			"of applying all documents together: access is allowed when any of the `allow` rules matches, " +
+			"and denied when any of the `deny` rules matches, regardless of the `allow` rules.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "documents",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The JSON PPL documents to merge. Must not be empty.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run merges the PPL documents.
+func (f *MergePPLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var documents []string
+	resp.Error = req.Arguments.Get(ctx, &documents)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(documents) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "At least one PPL document must be given.")
+		return
+	}
+
+	// Conditions of each action, in the order of the documents
+	conditions := map[string][]interface{}{}
+	for i, document := range documents {
+		ppl, err := parsePPL(document)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid PPL in document %d: %s", i, err))
+			return
+		}
+
+		rules, ok := ppl.([]interface{})
+		if !ok {
+			rules = []interface{}{ppl}
+		}
+		for _, rule := range rules {
+			for _, action := range sortedKeys(rule.(map[string]interface{})) {
+				operators := rule.(map[string]interface{})[action].(map[string]interface{})
+				conditions[action] = append(conditions[action], pplCondition(operators))
+			}
+		}
+	}
+
+	merged := map[string]interface{}{}
+	for action, actionConditions := range conditions {
+		if len(actionConditions) == 1 {
+			merged[action] = actionConditions[0]
+		} else {
+			merged[action] = map[string]interface{}{"or": actionConditions}
+		}
+	}
+
+	result, err := encodePPL(merged)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// pplCondition returns the logical operators of an action as a single condition,
+// combining multiple operators with "and" as PPL does.
+func pplCondition(operators map[string]interface{}) map[string]interface{} {
+	if len(operators) == 1 {
+		return operators
+	}
+
+	condition := make([]interface{}, 0, len(operators))
+	for _, operator := range sortedKeys(operators) {
+		condition = append(condition, map[string]interface{}{operator: operators[operator]})
+	}
+	return map[string]interface{}{"and": condition}
+}
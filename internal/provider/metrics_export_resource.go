@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MetricsExportResource{}
+var _ resource.ResourceWithImportState = &MetricsExportResource{}
+var _ resource.ResourceWithValidateConfig = &MetricsExportResource{}
+
+// NewMetricsExportResource creates a new MetricsExportResource.
+func NewMetricsExportResource() resource.Resource {
+	return &MetricsExportResource{}
+}
+
+// MetricsExportResource defines the resource implementation.
+type MetricsExportResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// MetricsExportResourceModel describes the resource data model.
+type MetricsExportResourceModel struct {
+	ID                    types.String                             `tfsdk:"id"`
+	ClusterID             types.String                             `tfsdk:"cluster_id"`
+	ScrapeInterval        durationValue                            `tfsdk:"scrape_interval"`
+	PrometheusRemoteWrite *MetricsExportPrometheusRemoteWriteModel `tfsdk:"prometheus_remote_write"`
+	Datadog               *MetricsExportDatadogModel               `tfsdk:"datadog"`
+}
+
+// MetricsExportPrometheusRemoteWriteModel describes the Prometheus remote-write export options.
+type MetricsExportPrometheusRemoteWriteModel struct {
+	URL         types.String `tfsdk:"url"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	BearerToken types.String `tfsdk:"bearer_token"`
+}
+
+// MetricsExportDatadogModel describes the Datadog export options.
+type MetricsExportDatadogModel struct {
+	Site   types.String `tfsdk:"site"`
+	APIKey types.String `tfsdk:"api_key"`
+}
+
+// Metadata sets the resource type name for the MetricsExportResource.
+func (r *MetricsExportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metrics_export"
+}
+
+// Schema defines the structure and attributes of the MetricsExportResource.
+func (r *MetricsExportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the metrics export of a Pomerium Zero cluster. " +
+			"Exactly one of `prometheus_remote_write` or `datadog` must be configured.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the metrics export. This corresponds to the cluster ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to export metrics from.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scrape_interval": schema.StringAttribute{
+				CustomType:          durationType{},
+				MarkdownDescription: "How often metrics are collected and exported, e.g. `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"prometheus_remote_write": schema.SingleNestedAttribute{
+				MarkdownDescription: "Export metrics to a Prometheus remote-write endpoint.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						MarkdownDescription: "The URL of the remote-write endpoint, e.g. `https://prometheus.example.com/api/v1/write`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringMatches(webhookURLPattern, "value must be an http or https URL"),
+						},
+					},
+					"username": schema.StringAttribute{
+						MarkdownDescription: "The username used for basic authentication.",
+						Optional:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "The password used for basic authentication.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"bearer_token": schema.StringAttribute{
+						MarkdownDescription: "The bearer token used for authentication.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"datadog": schema.SingleNestedAttribute{
+				MarkdownDescription: "Export metrics to Datadog.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"site": schema.StringAttribute{
+						MarkdownDescription: "The Datadog site to send metrics to, e.g. `datadoghq.eu`. Defaults to `datadoghq.com`.",
+						Optional:            true,
+					},
+					"api_key": schema.StringAttribute{
+						MarkdownDescription: "The Datadog API key.",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that exactly one export destination is configured.
+func (r *MetricsExportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data MetricsExportResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (data.PrometheusRemoteWrite == nil) == (data.Datadog == nil) {
+		resp.Diagnostics.AddError(
+			"Invalid Metrics Export Configuration",
+			"Exactly one of prometheus_remote_write or datadog must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the MetricsExportResource.
+func (r *MetricsExportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create configures the metrics export of a cluster.
+func (r *MetricsExportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan MetricsExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := r.putMetricsExport(ctx, plan.ClusterID.ValueString(), createMetricsExportRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating metrics export", err.Error())
+		return
+	}
+
+	plan.ID = plan.ClusterID
+	updateMetricsExportResourceModel(&plan, export)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the metrics export of a cluster.
+func (r *MetricsExportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state MetricsExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := r.getMetricsExport(ctx, state.ClusterID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading metrics export", err.Error())
+		return
+	}
+
+	updateMetricsExportResourceModel(&state, export)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the metrics export of a cluster.
+func (r *MetricsExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan MetricsExportResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	export, err := r.putMetricsExport(ctx, plan.ClusterID.ValueString(), createMetricsExportRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating metrics export", err.Error())
+		return
+	}
+
+	updateMetricsExportResourceModel(&plan, export)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete disables the metrics export of the cluster.
+func (r *MetricsExportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state MetricsExportResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/metrics-export", apiBaseURL, r.organizationID, state.ClusterID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting metrics export", err.Error())
+		return
+	}
+}
+
+// ImportState imports the metrics export of a cluster by the cluster ID.
+// Credentials are never returned by the API, so they have to be set in the configuration after importing.
+func (r *MetricsExportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	export, err := r.getMetricsExport(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing metrics export", fmt.Sprintf("Unable to read metrics export for %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := MetricsExportResourceModel{
+		ID:        types.StringValue(req.ID),
+		ClusterID: types.StringValue(req.ID),
+	}
+	updateMetricsExportResourceModel(&state, export)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getMetricsExport fetches the metrics export of a cluster.
+func (r *MetricsExportResource) getMetricsExport(ctx context.Context, clusterID string) (*MetricsExport, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/metrics-export", apiBaseURL, r.organizationID, clusterID)
+
+	var export MetricsExport
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &export); err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// putMetricsExport creates or replaces the metrics export of a cluster.
+func (r *MetricsExportResource) putMetricsExport(ctx context.Context, clusterID string, export MetricsExport) (*MetricsExport, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/metrics-export", apiBaseURL, r.organizationID, clusterID)
+
+	var updated MetricsExport
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, export, http.StatusOK, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// createMetricsExportRequest creates the API request body from the MetricsExportResourceModel.
+func createMetricsExportRequest(model MetricsExportResourceModel) MetricsExport {
+	export := MetricsExport{
+		ScrapeInterval: model.ScrapeInterval.ValueString(),
+		Options:        map[string]string{},
+	}
+
+	setOption := func(key string, value types.String) {
+		if !value.IsNull() {
+			export.Options[key] = value.ValueString()
+		}
+	}
+
+	switch {
+	case model.PrometheusRemoteWrite != nil:
+		export.Type = "prometheus_remote_write"
+		setOption("url", model.PrometheusRemoteWrite.URL)
+		setOption("username", model.PrometheusRemoteWrite.Username)
+		setOption("password", model.PrometheusRemoteWrite.Password)
+		setOption("bearerToken", model.PrometheusRemoteWrite.BearerToken)
+	case model.Datadog != nil:
+		export.Type = "datadog"
+		setOption("site", model.Datadog.Site)
+		setOption("apiKey", model.Datadog.APIKey)
+	}
+
+	return export
+}
+
+// updateMetricsExportResourceModel updates the model with the metrics export returned by the API.
+// Credentials are not returned by the API, so the values already in the model are kept.
+func updateMetricsExportResourceModel(model *MetricsExportResourceModel, export *MetricsExport) {
+	model.ScrapeInterval = durationValueOrNull(export.ScrapeInterval)
+
+	switch export.Type {
+	case "prometheus_remote_write":
+		prometheus := &MetricsExportPrometheusRemoteWriteModel{Password: types.StringNull(), BearerToken: types.StringNull()}
+		if model.PrometheusRemoteWrite != nil {
+			prometheus.Password = model.PrometheusRemoteWrite.Password
+			prometheus.BearerToken = model.PrometheusRemoteWrite.BearerToken
+		}
+		prometheus.URL = types.StringValue(export.Options["url"])
+		prometheus.Username = stringValueOrNull(export.Options["username"])
+		model.PrometheusRemoteWrite, model.Datadog = prometheus, nil
+	case "datadog":
+		datadog := &MetricsExportDatadogModel{APIKey: types.StringNull()}
+		if model.Datadog != nil {
+			datadog.APIKey = model.Datadog.APIKey
+		}
+		datadog.Site = stringValueOrNull(export.Options["site"])
+		model.PrometheusRemoteWrite, model.Datadog = nil, datadog
+	}
+}
@@ -4,14 +4,17 @@ import "encoding/json"
 
 // Cluster represents a Pomerium Zero cluster
 type Cluster struct {
-	ID                  string `json:"id"`
-	Name                string `json:"name"`
-	NamespaceID         string `json:"namespaceId"`
-	Domain              string `json:"domain"`
-	FQDN                string `json:"fqdn"`
-	AutoDetectIPAddress string `json:"autoDetectIpAddress"`
-	CreatedAt           string `json:"createdAt"`
-	UpdatedAt           string `json:"updatedAt"`
+	ID                    string `json:"id"`
+	Name                  string `json:"name"`
+	NamespaceID           string `json:"namespaceId"`
+	Domain                string `json:"domain"`
+	FQDN                  string `json:"fqdn"`
+	AutoDetectIPAddress   string `json:"autoDetectIpAddress"`
+	CreatedAt             string `json:"createdAt"`
+	UpdatedAt             string `json:"updatedAt"`
+	Version               string `json:"version"`
+	ConnectivityState     string `json:"connectivityState"`
+	PendingChangesetCount int64  `json:"pendingChangesetCount"`
 }
 
 // Policy represents a Pomerium Zero policy
@@ -29,3 +32,247 @@ type Policy struct {
 		Name string `json:"name"`
 	} `json:"routes"`
 }
+
+// Changeset represents a set of configuration changes on a Pomerium Zero cluster
+type Changeset struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// DirectoryProvider represents the directory sync configuration of a Pomerium Zero cluster
+type DirectoryProvider struct {
+	Provider        string            `json:"provider"`
+	RefreshInterval string            `json:"refreshInterval,omitempty"`
+	RefreshTimeout  string            `json:"refreshTimeout,omitempty"`
+	Options         map[string]string `json:"options"`
+	LastSyncedAt    string            `json:"lastSyncedAt,omitempty"`
+}
+
+// RoleAssignment represents the role of a member or service account in a Pomerium Zero organization or namespace
+type RoleAssignment struct {
+	ID               string `json:"id,omitempty"`
+	Role             string `json:"role"`
+	UserID           string `json:"userId,omitempty"`
+	ServiceAccountID string `json:"serviceAccountId,omitempty"`
+	NamespaceID      string `json:"namespaceId,omitempty"`
+}
+
+// NotificationWebhook represents a webhook that receives notifications about events in a Pomerium Zero organization
+type NotificationWebhook struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Events  []string `json:"events"`
+	Secret  string   `json:"secret,omitempty"`
+	Enabled bool     `json:"enabled"`
+}
+
+// BootstrapToken represents a short-lived token used to join a Pomerium instance to a Pomerium Zero cluster
+type BootstrapToken struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// SessionRevocation represents the result of revoking the sessions of a Pomerium Zero cluster
+type SessionRevocation struct {
+	RevokedCount int64  `json:"revokedCount"`
+	RevokedAt    string `json:"revokedAt"`
+}
+
+// AccessToken represents a short-lived, scoped Pomerium Zero console API token
+type AccessToken struct {
+	ID        string `json:"id"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// NamespacePermission represents permissions granted to a member or service account on a Pomerium Zero namespace
+type NamespacePermission struct {
+	ID               string   `json:"id,omitempty"`
+	NamespaceID      string   `json:"namespaceId,omitempty"`
+	UserID           string   `json:"userId,omitempty"`
+	ServiceAccountID string   `json:"serviceAccountId,omitempty"`
+	Permissions      []string `json:"permissions"`
+	Inherit          bool     `json:"inherit"`
+}
+
+// MetricsExport represents the metrics export configuration of a Pomerium Zero cluster
+type MetricsExport struct {
+	Type           string            `json:"type"`
+	ScrapeInterval string            `json:"scrapeInterval,omitempty"`
+	Options        map[string]string `json:"options"`
+}
+
+// LogExport represents a log drain shipping the logs of a Pomerium Zero cluster to an external destination
+type LogExport struct {
+	ID       string            `json:"id,omitempty"`
+	Name     string            `json:"name"`
+	URL      string            `json:"url"`
+	Format   string            `json:"format"`
+	LogTypes []string          `json:"logTypes"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Enabled  bool              `json:"enabled"`
+}
+
+// APIToken represents a Pomerium Zero organization API token. The token secret is only returned on creation.
+type APIToken struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	NamespaceID string `json:"namespaceId,omitempty"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
+	Token       string `json:"token,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+	LastUsedAt  string `json:"lastUsedAt,omitempty"`
+	Revoked     bool   `json:"revoked,omitempty"`
+}
+
+// ConfigSnapshot represents a snapshot of the full configuration of a Pomerium Zero cluster
+type ConfigSnapshot struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Revision    string `json:"revision,omitempty"`
+	CreatedAt   string `json:"createdAt,omitempty"`
+}
+
+// AlertRule represents a rule that sends notifications when a condition in a Pomerium Zero organization is met
+type AlertRule struct {
+	ID                     string   `json:"id,omitempty"`
+	Name                   string   `json:"name"`
+	Type                   string   `json:"type"`
+	ClusterID              string   `json:"clusterId,omitempty"`
+	Threshold              string   `json:"threshold,omitempty"`
+	NotificationWebhookIDs []string `json:"notificationWebhookIds,omitempty"`
+	Emails                 []string `json:"emails,omitempty"`
+	Enabled                bool     `json:"enabled"`
+}
+
+// Namespace represents a namespace in a Pomerium Zero organization
+type Namespace struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ParentID  string `json:"parentId"`
+	ClusterID string `json:"clusterId"`
+}
+
+// Organization represents a Pomerium Zero organization
+type Organization struct {
+	ID     string           `json:"id"`
+	Name   string           `json:"name"`
+	Plan   string           `json:"plan"`
+	Limits map[string]int64 `json:"limits"`
+}
+
+// Certificate represents a TLS certificate on a Pomerium Zero cluster
+type Certificate struct {
+	ID        string   `json:"id"`
+	Domains   []string `json:"domains"`
+	Issuer    string   `json:"issuer"`
+	NotBefore string   `json:"notBefore"`
+	NotAfter  string   `json:"notAfter"`
+	Managed   bool     `json:"managed"`
+}
+
+// Device represents a device enrolled in a Pomerium Zero organization
+type Device struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	OwnerID       string `json:"ownerId"`
+	OwnerEmail    string `json:"ownerEmail"`
+	Type          string `json:"type"`
+	ApprovalState string `json:"approvalState"`
+	EnrolledAt    string `json:"enrolledAt"`
+}
+
+// ActivityLogEntry represents an entry of the activity log of a Pomerium Zero organization
+type ActivityLogEntry struct {
+	ID           string `json:"id"`
+	Actor        string `json:"actor"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// ActivityLogPage represents a page of activity log entries
+type ActivityLogPage struct {
+	Entries    []ActivityLogEntry `json:"entries"`
+	NextCursor string             `json:"nextCursor"`
+}
+
+// CurrentUser represents the identity behind the API token the provider is authenticated with
+type CurrentUser struct {
+	Subject       string `json:"subject"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Organizations []struct {
+		ID    string   `json:"id"`
+		Name  string   `json:"name"`
+		Roles []string `json:"roles"`
+	} `json:"organizations"`
+}
+
+// ClusterStatus represents the health of a Pomerium Zero cluster
+type ClusterStatus struct {
+	Status             string `json:"status"`
+	ConnectedInstances int64  `json:"connectedInstances"`
+	PomeriumVersion    string `json:"pomeriumVersion"`
+	LastHeartbeatAt    string `json:"lastHeartbeatAt"`
+	CertificateStatus  string `json:"certificateStatus"`
+}
+
+// JSONWebKey represents a public key used to sign the assertion JWTs of a Pomerium Zero cluster
+type JSONWebKey struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+}
+
+// IdentityProviderType represents an identity provider type supported by Pomerium Zero
+type IdentityProviderType struct {
+	Type           string   `json:"type"`
+	DisplayName    string   `json:"displayName"`
+	RequiredFields []string `json:"requiredFields"`
+	OptionalFields []string `json:"optionalFields"`
+}
+
+// PolicyTemplate represents a built-in policy template of the Pomerium Zero console
+type PolicyTemplate struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	PPL         json.RawMessage `json:"ppl"`
+}
+
+// Member represents a member of a Pomerium Zero organization
+type Member struct {
+	ID          string `json:"id"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	LastLoginAt string `json:"lastLoginAt"`
+}
+
+// Release represents a Pomerium version available to Pomerium Zero clusters
+type Release struct {
+	Version    string `json:"version"`
+	Channel    string `json:"channel"`
+	ReleasedAt string `json:"releasedAt"`
+}
+
+// Usage represents the usage of the quotas of a Pomerium Zero organization
+type Usage struct {
+	Clusters Quota `json:"clusters"`
+	Routes   Quota `json:"routes"`
+	Seats    Quota `json:"seats"`
+}
+
+// Quota represents how much of a quota is used. A nil Limit means that the quota is unlimited.
+type Quota struct {
+	Used  int64  `json:"used"`
+	Limit *int64 `json:"limit"`
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// checkPolicyNamespaces returns an error for each policy that routes of the namespace cannot use, i.e.
+// each policy that is neither in the namespace nor in one of its parents. The API only rejects such
+// routes on apply, with a message that does not name the policy. Policies and namespaces are listed
+// through the cache of the provider, so checking many routes lists them once. Policies that are not
+// found, e.g. because they are created in the same apply, are not checked, and errors are only
+// logged, as the API performs the authoritative check.
+func checkPolicyNamespaces(ctx context.Context, client *http.Client, token, organizationID, namespaceID string, policyIDs []string, attributePath func(int) path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if namespaceID == "" || len(policyIDs) == 0 {
+		return diags
+	}
+
+	var policies []Policy
+	url := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, organizationID)
+	if err := doAPIRequest(ctx, client, token, "GET", url, nil, http.StatusOK, &policies); err != nil {
+		log.Printf("[DEBUG] Unable to list policies to check their namespaces: %s", err)
+		return diags
+	}
+
+	var namespaces []Namespace
+	url = fmt.Sprintf("%s/organizations/%s/namespaces", apiBaseURL, organizationID)
+	if err := doAPIRequest(ctx, client, token, "GET", url, nil, http.StatusOK, &namespaces); err != nil {
+		log.Printf("[DEBUG] Unable to list namespaces to check the namespaces of policies: %s", err)
+		return diags
+	}
+
+	namespacesByID := make(map[string]Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		namespacesByID[namespace.ID] = namespace
+	}
+
+	// The namespace and its parents, guarding against cycles
+	allowed := make(map[string]bool)
+	for id := namespaceID; id != "" && !allowed[id]; id = namespacesByID[id].ParentID {
+		allowed[id] = true
+	}
+
+	policyNamespaces := make(map[string]string, len(policies))
+	for _, policy := range policies {
+		policyNamespaces[policy.ID] = policy.NamespaceID
+	}
+
+	for i, policyID := range policyIDs {
+		policyNamespaceID, ok := policyNamespaces[policyID]
+		if !ok || allowed[policyNamespaceID] {
+			continue
+		}
+
+		diags.AddAttributeError(
+			attributePath(i),
+			"Policy In Another Namespace",
+			fmt.Sprintf("The policy %s is in namespace %s, but the route is in namespace %s. "+
+				"Routes can only use policies of their own namespace or of a parent namespace.",
+				policyID, namespaceLabel(namespacesByID, policyNamespaceID), namespaceLabel(namespacesByID, namespaceID)),
+		)
+	}
+
+	return diags
+}
+
+// namespaceLabel returns the name and ID of a namespace for messages, or only the ID when the namespace is unknown.
+func namespaceLabel(namespaces map[string]Namespace, id string) string {
+	if namespace, ok := namespaces[id]; ok && namespace.Name != "" {
+		return fmt.Sprintf("%q (%s)", namespace.Name, id)
+	}
+	return id
+}
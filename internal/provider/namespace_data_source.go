@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NamespaceDataSource{}
+
+// NewNamespaceDataSource creates a new NamespaceDataSource.
+func NewNamespaceDataSource() datasource.DataSource {
+	return &NamespaceDataSource{}
+}
+
+// NamespaceDataSource defines the data source implementation.
+type NamespaceDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// NamespaceDataSourceModel describes the data source data model.
+type NamespaceDataSourceModel struct {
+	Path      types.String `tfsdk:"path"`
+	ClusterID types.String `tfsdk:"cluster_id"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	ParentID  types.String `tfsdk:"parent_id"`
+}
+
+// Metadata sets the data source type name for the NamespaceDataSource.
+func (d *NamespaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_namespace"
+}
+
+// Schema defines the structure and attributes of the NamespaceDataSource.
+func (d *NamespaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single namespace by its path, e.g. `Production/team-a`, or by its name, " +
+			"so that modules can refer to namespaces without hard-coding their IDs. Fails when no namespace or more than one matches.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The names of the namespace and its ancestors, separated by slashes, starting at the root namespace. " +
+					"A name without slashes also matches a namespace with that name at any depth, if it is the only one.",
+				Required: true,
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster to look up the namespace in. If not set, all namespaces of the organization are searched. " +
+					"Set to the ID of the cluster the namespace belongs to, if any.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringIsID(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the namespace.",
+				Computed:            true,
+			},
+			"parent_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the parent namespace. Not set for root namespaces.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the NamespaceDataSource.
+func (d *NamespaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the namespaces and looks up the one matching the path.
+func (d *NamespaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NamespaceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/namespaces", apiBaseURL, d.organizationID)
+
+	var namespaces []Namespace
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &namespaces); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error fetching namespaces", "Unable to list namespaces", err)...)
+		return
+	}
+
+	byID := make(map[string]Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		byID[namespace.ID] = namespace
+	}
+
+	wantPath := strings.Trim(data.Path.ValueString(), "/")
+	clusterID := data.ClusterID.ValueString()
+
+	// Matches by path take precedence over matches by name
+	var byPath, byName []Namespace
+	for _, namespace := range namespaces {
+		if clusterID != "" && namespace.ClusterID != clusterID {
+			continue
+		}
+		switch {
+		case namespacePath(namespace, byID) == wantPath:
+			byPath = append(byPath, namespace)
+		case !strings.Contains(wantPath, "/") && namespace.Name == wantPath:
+			byName = append(byName, namespace)
+		}
+	}
+	matches := byPath
+	if len(matches) == 0 {
+		matches = byName
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Namespace Not Found",
+			fmt.Sprintf("No namespace matches the path %q. Check the path, or list the paths of all namespaces with the pomeriumzero_namespaces data source.", wantPath),
+		)
+		return
+	case 1:
+	default:
+		paths := make([]string, 0, len(matches))
+		for _, namespace := range matches {
+			paths = append(paths, fmt.Sprintf("%s (%s)", namespacePath(namespace, byID), namespace.ID))
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("path"),
+			"Ambiguous Namespace",
+			fmt.Sprintf("The path %q matches %d namespaces: %s. Use the full path of the namespace, or set cluster_id.",
+				wantPath, len(matches), strings.Join(paths, ", ")),
+		)
+		return
+	}
+
+	namespace := matches[0]
+	data.ID = types.StringValue(namespace.ID)
+	data.Name = types.StringValue(namespace.Name)
+	data.ParentID = stringValueOrNull(namespace.ParentID)
+	data.ClusterID = stringValueOrNull(namespace.ClusterID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
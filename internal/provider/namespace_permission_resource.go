@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NamespacePermissionResource{}
+var _ resource.ResourceWithImportState = &NamespacePermissionResource{}
+var _ resource.ResourceWithValidateConfig = &NamespacePermissionResource{}
+
+// namespacePermissions lists the permissions that can be granted on a namespace.
+var namespacePermissions = []string{
+	"routes:read",
+	"routes:write",
+	"policies:read",
+	"policies:write",
+	"certificates:read",
+	"certificates:write",
+	"namespaces:read",
+	"namespaces:write",
+}
+
+// NewNamespacePermissionResource creates a new NamespacePermissionResource.
+func NewNamespacePermissionResource() resource.Resource {
+	return &NamespacePermissionResource{}
+}
+
+// NamespacePermissionResource defines the resource implementation.
+type NamespacePermissionResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// NamespacePermissionResourceModel describes the resource data model.
+type NamespacePermissionResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	NamespaceID      types.String `tfsdk:"namespace_id"`
+	UserID           types.String `tfsdk:"user_id"`
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	Permissions      types.Set    `tfsdk:"permissions"`
+	Inherit          types.Bool   `tfsdk:"inherit"`
+}
+
+// Metadata sets the resource type name for the NamespacePermissionResource.
+func (r *NamespacePermissionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_namespace_permission"
+}
+
+// Schema defines the structure and attributes of the NamespacePermissionResource.
+func (r *NamespacePermissionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a member or a service account fine-grained permissions on a single Pomerium Zero namespace, " +
+			"e.g. to delegate the administration of an app team's namespace. Exactly one of `user_id` or `service_account_id` must be configured. " +
+			"Use `pomeriumzero_role_assignment` to assign a predefined role instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace permission.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace the permissions are granted on.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the member the permissions are granted to.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the service account the permissions are granted to.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				MarkdownDescription: "The permissions to grant. Valid values are `routes:read`, `routes:write`, `policies:read`, `policies:write`, " +
+					"`certificates:read`, `certificates:write`, `namespaces:read` and `namespaces:write`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setElementsOneOf(namespacePermissions...),
+				},
+			},
+			"inherit": schema.BoolAttribute{
+				MarkdownDescription: "Whether the permissions also apply to the child namespaces of the namespace. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that the permissions are granted to exactly one principal.
+func (r *NamespacePermissionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NamespacePermissionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The principal may not be known until apply
+	if data.UserID.IsUnknown() || data.ServiceAccountID.IsUnknown() {
+		return
+	}
+
+	if data.UserID.IsNull() == data.ServiceAccountID.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Namespace Permission Configuration",
+			"Exactly one of user_id or service_account_id must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the NamespacePermissionResource.
+func (r *NamespacePermissionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create grants the permissions.
+func (r *NamespacePermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NamespacePermissionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissionReq, diags := createNamespacePermissionRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/namespaces/%s/permissions", apiBaseURL, r.organizationID, plan.NamespaceID.ValueString())
+
+	var permission NamespacePermission
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, permissionReq, http.StatusCreated, &permission); err != nil {
+		resp.Diagnostics.AddError("Error creating namespace permission", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNamespacePermissionResourceModel(ctx, &plan, &permission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the namespace permission.
+func (r *NamespacePermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NamespacePermissionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permission, err := r.getNamespacePermission(ctx, state.NamespaceID.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading namespace permission", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNamespacePermissionResourceModel(ctx, &state, permission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the granted permissions. Changing the principal or the namespace replaces the resource.
+func (r *NamespacePermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NamespacePermissionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissionReq, diags := createNamespacePermissionRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/namespaces/%s/permissions/%s", apiBaseURL, r.organizationID, plan.NamespaceID.ValueString(), plan.ID.ValueString())
+
+	var permission NamespacePermission
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, permissionReq, http.StatusOK, &permission); err != nil {
+		resp.Diagnostics.AddError("Error updating namespace permission", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNamespacePermissionResourceModel(ctx, &plan, &permission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete revokes the permissions.
+func (r *NamespacePermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NamespacePermissionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/namespaces/%s/permissions/%s", apiBaseURL, r.organizationID, state.NamespaceID.ValueString(), state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting namespace permission", err.Error())
+		return
+	}
+}
+
+// ImportState imports a namespace permission by the namespace ID and the permission ID, separated by a slash.
+func (r *NamespacePermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	namespaceID, permissionID, ok := strings.Cut(req.ID, "/")
+	if !ok || namespaceID == "" || permissionID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <namespace_id>/<permission_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	permission, err := r.getNamespacePermission(ctx, namespaceID, permissionID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing namespace permission", fmt.Sprintf("Unable to read namespace permission %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := NamespacePermissionResourceModel{NamespaceID: types.StringValue(namespaceID)}
+	resp.Diagnostics.Append(updateNamespacePermissionResourceModel(ctx, &state, permission)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getNamespacePermission fetches a namespace permission by its ID.
+func (r *NamespacePermissionResource) getNamespacePermission(ctx context.Context, namespaceID, permissionID string) (*NamespacePermission, error) {
+	url := fmt.Sprintf("%s/organizations/%s/namespaces/%s/permissions/%s", apiBaseURL, r.organizationID, namespaceID, permissionID)
+
+	var permission NamespacePermission
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &permission); err != nil {
+		return nil, err
+	}
+
+	return &permission, nil
+}
+
+// createNamespacePermissionRequest creates the API request body from the NamespacePermissionResourceModel.
+func createNamespacePermissionRequest(ctx context.Context, model NamespacePermissionResourceModel) (NamespacePermission, diag.Diagnostics) {
+	permission := NamespacePermission{
+		UserID:           model.UserID.ValueString(),
+		ServiceAccountID: model.ServiceAccountID.ValueString(),
+		Inherit:          model.Inherit.ValueBool(),
+	}
+
+	diags := model.Permissions.ElementsAs(ctx, &permission.Permissions, false)
+	return permission, diags
+}
+
+// updateNamespacePermissionResourceModel updates the model with the namespace permission returned by the API.
+func updateNamespacePermissionResourceModel(ctx context.Context, model *NamespacePermissionResourceModel, permission *NamespacePermission) diag.Diagnostics {
+	model.ID = types.StringValue(permission.ID)
+	if permission.NamespaceID != "" {
+		model.NamespaceID = types.StringValue(permission.NamespaceID)
+	}
+	model.UserID = stringValueOrNull(permission.UserID)
+	model.ServiceAccountID = stringValueOrNull(permission.ServiceAccountID)
+	model.Inherit = types.BoolValue(permission.Inherit)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, permission.Permissions)
+	model.Permissions = permissions
+	return diags
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NamespacesDataSource{}
+
+// NewNamespacesDataSource creates a new NamespacesDataSource.
+func NewNamespacesDataSource() datasource.DataSource {
+	return &NamespacesDataSource{}
+}
+
+// NamespacesDataSource defines the data source implementation.
+type NamespacesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// NamespacesDataSourceModel describes the data source data model.
+type NamespacesDataSourceModel struct {
+	Namespaces []NamespaceDataModel `tfsdk:"namespaces"`
+	IDsByPath  map[string]string    `tfsdk:"ids_by_path"`
+}
+
+// NamespaceDataModel describes a single namespace returned by the NamespacesDataSource.
+type NamespaceDataModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Path      types.String `tfsdk:"path"`
+	ParentID  types.String `tfsdk:"parent_id"`
+	ClusterID types.String `tfsdk:"cluster_id"`
+}
+
+// Metadata sets the data source type name for the NamespacesDataSource.
+func (d *NamespacesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_namespaces"
+}
+
+// Schema defines the structure and attributes of the NamespacesDataSource.
+func (d *NamespacesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the namespaces in the organization, so namespace IDs can be resolved by their path, " +
+			"e.g. `Production/team-a`, instead of hard-coding them.",
+		Attributes: map[string]schema.Attribute{
+			"namespaces": schema.ListNestedAttribute{
+				MarkdownDescription: "The namespaces in the organization.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the namespace.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the namespace.",
+							Computed:            true,
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "The names of the namespace and its ancestors, separated by slashes, starting at the root namespace.",
+							Computed:            true,
+						},
+						"parent_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the parent namespace. Not set for root namespaces.",
+							Computed:            true,
+						},
+						"cluster_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the cluster the namespace belongs to. Not set for namespaces that do not belong to a cluster.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"ids_by_path": schema.MapAttribute{
+				MarkdownDescription: "The IDs of the namespaces, keyed by path.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the NamespacesDataSource.
+func (d *NamespacesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the namespaces and resolves their paths.
+func (d *NamespacesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	url := fmt.Sprintf("%s/organizations/%s/namespaces", apiBaseURL, d.organizationID)
+
+	var namespaces []Namespace
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &namespaces); err != nil {
+		resp.Diagnostics.AddError("Error fetching namespaces", err.Error())
+		return
+	}
+
+	byID := make(map[string]Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		byID[namespace.ID] = namespace
+	}
+
+	data := NamespacesDataSourceModel{
+		Namespaces: []NamespaceDataModel{},
+		IDsByPath:  make(map[string]string, len(namespaces)),
+	}
+	for _, namespace := range namespaces {
+		path := namespacePath(namespace, byID)
+		data.Namespaces = append(data.Namespaces, NamespaceDataModel{
+			ID:        types.StringValue(namespace.ID),
+			Name:      types.StringValue(namespace.Name),
+			Path:      types.StringValue(path),
+			ParentID:  stringValueOrNull(namespace.ParentID),
+			ClusterID: stringValueOrNull(namespace.ClusterID),
+		})
+		data.IDsByPath[path] = namespace.ID
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// namespacePath joins the names of the namespace and its ancestors with slashes, starting at the root.
+// Unknown parents and cycles end the path, so that inconsistent API responses do not hang the provider.
+func namespacePath(namespace Namespace, byID map[string]Namespace) string {
+	names := []string{namespace.Name}
+	seen := map[string]bool{namespace.ID: true}
+	for parent, ok := byID[namespace.ParentID]; ok && !seen[parent.ID]; parent, ok = byID[parent.ParentID] {
+		seen[parent.ID] = true
+		names = append([]string{parent.Name}, names...)
+	}
+	return strings.Join(names, "/")
+}
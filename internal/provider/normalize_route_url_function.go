@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	// routeURLSchemes lists the schemes Pomerium accepts in the from and to URLs of a route.
+	routeURLSchemes = append(append([]string{}, routeToSchemes...), "tcp+https", "udp+https")
+
+	// routeURLDefaultPorts maps route URL schemes to the port implied when none is given.
+	routeURLDefaultPorts = map[string]string{"http": "80", "https": "443", "h2c": "80"}
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeRouteURLFunction{}
+
+// NewNormalizeRouteURLFunction creates a new NormalizeRouteURLFunction.
+func NewNormalizeRouteURLFunction() function.Function {
+	return &NormalizeRouteURLFunction{}
+}
+
+// NormalizeRouteURLFunction normalizes the from or to URL of a route.
+type NormalizeRouteURLFunction struct{}
+
+// Metadata sets the name of the NormalizeRouteURLFunction.
+func (f *NormalizeRouteURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_route_url"
+}
+
+// Definition defines the parameters and return type of the NormalizeRouteURLFunction.
+func (f *NormalizeRouteURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a route URL",
+		MarkdownDescription: "Normalizes a `from` or `to` URL of `pomeriumzero_route` to the form stored by Pomerium Zero: " +
+			"`https://` is assumed when no scheme is given, the scheme and host are lowercased, default ports " +
+			"(`80` for `http` and `h2c`, `443` for `https`) and a trailing `/` without further path are removed. " +
+			"An error is raised for URLs without a host or with a scheme other than `" + strings.Join(routeURLSchemes, "`, `") + "`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "The URL or hostname to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the URL.
+func (f *NormalizeRouteURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = req.Arguments.Get(ctx, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeRouteURL(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid route URL %q: %s.", value, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+// normalizeRouteURL normalizes a route URL, see NormalizeRouteURLFunction.
+func normalizeRouteURL(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if !containsString(routeURLSchemes, u.Scheme) {
+		return "", fmt.Errorf("scheme must be one of %s", strings.Join(routeURLSchemes, ", "))
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return "", fmt.Errorf("host must not be empty")
+	}
+	port := u.Port()
+	if port == routeURLDefaultPorts[u.Scheme] {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	} else {
+		u.Host = host
+	}
+
+	if u.Path == "/" && u.RawQuery == "" && u.Fragment == "" {
+		u.Path = ""
+	}
+
+	return u.String(), nil
+}
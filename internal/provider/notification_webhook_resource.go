@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NotificationWebhookResource{}
+var _ resource.ResourceWithImportState = &NotificationWebhookResource{}
+
+var (
+	// notificationEvents lists the events a notification webhook can subscribe to.
+	notificationEvents = []string{
+		"cluster.healthy",
+		"cluster.unhealthy",
+		"changeset.created",
+		"changeset.applied",
+		"changeset.failed",
+		"certificate.expiring",
+	}
+
+	// webhookURLPattern matches http and https URLs.
+	webhookURLPattern = regexp.MustCompile(`^https?://\S+$`)
+)
+
+// NewNotificationWebhookResource creates a new NotificationWebhookResource.
+func NewNotificationWebhookResource() resource.Resource {
+	return &NotificationWebhookResource{}
+}
+
+// NotificationWebhookResource defines the resource implementation.
+type NotificationWebhookResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// NotificationWebhookResourceModel describes the resource data model.
+type NotificationWebhookResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	URL     types.String `tfsdk:"url"`
+	Events  types.Set    `tfsdk:"events"`
+	Secret  types.String `tfsdk:"secret"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata sets the resource type name for the NotificationWebhookResource.
+func (r *NotificationWebhookResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_webhook"
+}
+
+// Schema defines the structure and attributes of the NotificationWebhookResource.
+func (r *NotificationWebhookResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a webhook that is called when events occur in the Pomerium Zero organization, " +
+			"e.g. to route cluster health and changeset notifications to Slack or PagerDuty.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the notification webhook.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the notification webhook.",
+				Required:            true,
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The endpoint the notifications are posted to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringMatches(webhookURLPattern, "value must be an http or https URL"),
+				},
+			},
+			"events": schema.SetAttribute{
+				MarkdownDescription: "The events that trigger a notification. Valid values are `cluster.healthy`, `cluster.unhealthy`, " +
+					"`changeset.created`, `changeset.applied`, `changeset.failed` and `certificate.expiring`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setElementsOneOf(notificationEvents...),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The secret used to sign the notifications, so the endpoint can verify that they were sent by Pomerium Zero. " +
+					"The secret is not returned by the API, so changes made outside of Terraform are not detected.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether notifications are sent to the webhook. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the NotificationWebhookResource.
+func (r *NotificationWebhookResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create creates the notification webhook.
+func (r *NotificationWebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan NotificationWebhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookReq, diags := createNotificationWebhookRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/notification-webhooks", apiBaseURL, r.organizationID)
+
+	var webhook NotificationWebhook
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, webhookReq, http.StatusCreated, &webhook); err != nil {
+		resp.Diagnostics.AddError("Error creating notification webhook", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNotificationWebhookResourceModel(ctx, &plan, &webhook)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the notification webhook.
+func (r *NotificationWebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state NotificationWebhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.getNotificationWebhook(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading notification webhook", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNotificationWebhookResourceModel(ctx, &state, webhook)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the notification webhook.
+func (r *NotificationWebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan NotificationWebhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookReq, diags := createNotificationWebhookRequest(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/notification-webhooks/%s", apiBaseURL, r.organizationID, plan.ID.ValueString())
+
+	var webhook NotificationWebhook
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, webhookReq, http.StatusOK, &webhook); err != nil {
+		resp.Diagnostics.AddError("Error updating notification webhook", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(updateNotificationWebhookResourceModel(ctx, &plan, &webhook)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the notification webhook.
+func (r *NotificationWebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state NotificationWebhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/notification-webhooks/%s", apiBaseURL, r.organizationID, state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting notification webhook", err.Error())
+		return
+	}
+}
+
+// ImportState imports a notification webhook by its ID.
+// The secret is never returned by the API, so it has to be set in the configuration after importing.
+func (r *NotificationWebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	webhook, err := r.getNotificationWebhook(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing notification webhook", fmt.Sprintf("Unable to read notification webhook %s, error: %s", req.ID, err))
+		return
+	}
+
+	state := NotificationWebhookResourceModel{Secret: types.StringNull()}
+	resp.Diagnostics.Append(updateNotificationWebhookResourceModel(ctx, &state, webhook)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getNotificationWebhook fetches a notification webhook by its ID.
+func (r *NotificationWebhookResource) getNotificationWebhook(ctx context.Context, webhookID string) (*NotificationWebhook, error) {
+	url := fmt.Sprintf("%s/organizations/%s/notification-webhooks/%s", apiBaseURL, r.organizationID, webhookID)
+
+	var webhook NotificationWebhook
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &webhook); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// createNotificationWebhookRequest creates the API request body from the NotificationWebhookResourceModel.
+func createNotificationWebhookRequest(ctx context.Context, model NotificationWebhookResourceModel) (NotificationWebhook, diag.Diagnostics) {
+	webhook := NotificationWebhook{
+		Name:    model.Name.ValueString(),
+		URL:     model.URL.ValueString(),
+		Secret:  model.Secret.ValueString(),
+		Enabled: model.Enabled.ValueBool(),
+	}
+
+	diags := model.Events.ElementsAs(ctx, &webhook.Events, false)
+	return webhook, diags
+}
+
+// updateNotificationWebhookResourceModel updates the model with the notification webhook returned by the API.
+// The secret is not returned by the API, so the value already in the model is kept.
+func updateNotificationWebhookResourceModel(ctx context.Context, model *NotificationWebhookResourceModel, webhook *NotificationWebhook) diag.Diagnostics {
+	model.ID = types.StringValue(webhook.ID)
+	model.Name = types.StringValue(webhook.Name)
+	model.URL = types.StringValue(webhook.URL)
+	model.Enabled = types.BoolValue(webhook.Enabled)
+
+	events, diags := types.SetValueFrom(ctx, types.StringType, webhook.Events)
+	model.Events = events
+	return diags
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// Endpoint exchanging an OIDC token of a CI system for a JWT
+	federatedTokenEndpoint = apiBaseURL + "/token/oidc"
+	// defaultOIDCAudience is the audience requested from GitHub Actions when the oidc block sets none.
+	defaultOIDCAudience = "https://console.pomerium.app"
+	// defaultOIDCTokenVariable is the environment variable read on GitLab when the oidc block sets none.
+	defaultOIDCTokenVariable = "POMERIUM_ZERO_ID_TOKEN"
+)
+
+// oidcModel describes the data model of the oidc block of the provider.
+type oidcModel struct {
+	Provider      types.String `tfsdk:"provider"`
+	Audience      types.String `tfsdk:"audience"`
+	TokenVariable types.String `tfsdk:"token_variable"`
+}
+
+// oidcBlock returns the schema of the oidc block of the provider.
+func oidcBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Authenticates with the OIDC token of the CI job running Terraform, instead of a long-lived API token. " +
+			"The CI system must be trusted by the Pomerium Zero organization. " +
+			"Exactly one of `api_token`, `api_token_file` and `oidc` must be set.",
+		Attributes: map[string]schema.Attribute{
+			"provider": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The CI system issuing the OIDC token. Must be one of `github` or `gitlab`. " +
+					"On GitHub Actions, the job needs the `id-token: write` permission.",
+				Validators: []validator.String{
+					stringOneOf("github", "gitlab"),
+				},
+			},
+			"audience": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The audience of the OIDC token requested from GitHub Actions. " +
+					"Defaults to `" + defaultOIDCAudience + "`. On GitLab, the audience is set with the `id_tokens` keyword of the job instead.",
+			},
+			"token_variable": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The environment variable holding the OIDC token on GitLab, as declared with the `id_tokens` keyword of the job. " +
+					"Defaults to `" + defaultOIDCTokenVariable + "`.",
+			},
+		},
+	}
+}
+
+// ciToken returns the OIDC token of the CI job running Terraform.
+func (m *oidcModel) ciToken(ctx context.Context) (string, error) {
+	switch m.Provider.ValueString() {
+	case "github":
+		audience := defaultOIDCAudience
+		if !m.Audience.IsNull() {
+			audience = m.Audience.ValueString()
+		}
+		return githubActionsToken(ctx, audience)
+	case "gitlab":
+		variable := defaultOIDCTokenVariable
+		if !m.TokenVariable.IsNull() {
+			variable = m.TokenVariable.ValueString()
+		}
+		token := os.Getenv(variable)
+		if token == "" {
+			return "", fmt.Errorf("the environment variable %s is not set. Declare it with the id_tokens keyword of the GitLab job", variable)
+		}
+		return token, nil
+	default:
+		return "", errors.New("oidc.provider must be set to github or gitlab")
+	}
+}
+
+// githubActionsToken requests an OIDC token for the given audience from GitHub Actions.
+func githubActionsToken(ctx context.Context, audience string) (string, error) {
+	requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+	requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL == "" || requestToken == "" {
+		return "", errors.New("ACTIONS_ID_TOKEN_REQUEST_URL is not set. Run Terraform in a GitHub Actions job with the id-token: write permission")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL+"&audience="+url.QueryEscape(audience), nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	// The token is requested from GitHub, so the transports of the API client do not apply
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting OIDC token from GitHub Actions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code from GitHub Actions: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding OIDC token response: %w", err)
+	}
+	return result.Value, nil
+}
+
+// getFederatedToken exchanges the OIDC token of a CI job for a JWT bearer token.
+func getFederatedToken(ctx context.Context, client *http.Client, subjectToken string) (string, error) {
+	payload, err := json.Marshal(map[string]string{"subjectToken": subjectToken})
+	if err != nil {
+		return "", err
+	}
+
+	log.Println("Sending request to federated token endpoint")
+	req, err := http.NewRequestWithContext(ctx, "POST", federatedTokenEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Println("Error making request:", err)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Unexpected status code: %d", resp.StatusCode)
+		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		IDToken string `json:"idToken"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		log.Println("Error decoding response:", err)
+		return "", err
+	}
+
+	return result.IDToken, nil
+}
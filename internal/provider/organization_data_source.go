@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationDataSource{}
+
+// NewOrganizationDataSource creates a new OrganizationDataSource.
+func NewOrganizationDataSource() datasource.DataSource {
+	return &OrganizationDataSource{}
+}
+
+// OrganizationDataSource defines the data source implementation.
+type OrganizationDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// OrganizationDataSourceModel describes the data source data model.
+type OrganizationDataSourceModel struct {
+	ID     types.String     `tfsdk:"id"`
+	Name   types.String     `tfsdk:"name"`
+	Plan   types.String     `tfsdk:"plan"`
+	Limits map[string]int64 `tfsdk:"limits"`
+}
+
+// Metadata sets the data source type name for the OrganizationDataSource.
+func (d *OrganizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+// Schema defines the structure and attributes of the OrganizationDataSource.
+func (d *OrganizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exposes the organization the provider is authenticated against, e.g. to branch on the capabilities of its plan.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the organization.",
+				Computed:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "The plan the organization is subscribed to, e.g. `personal`, `business` or `enterprise`.",
+				Computed:            true,
+			},
+			"limits": schema.MapAttribute{
+				MarkdownDescription: "The limits of the plan, keyed by what they limit, e.g. `clusters`, `routes` or `members`.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the OrganizationDataSource.
+func (d *OrganizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read retrieves the organization.
+func (d *OrganizationDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	url := fmt.Sprintf("%s/organizations/%s", apiBaseURL, d.organizationID)
+
+	var organization Organization
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &organization); err != nil {
+		resp.Diagnostics.AddError("Error fetching organization", err.Error())
+		return
+	}
+
+	data := OrganizationDataSourceModel{
+		ID:     types.StringValue(organization.ID),
+		Name:   types.StringValue(organization.Name),
+		Plan:   stringValueOrNull(organization.Plan),
+		Limits: organization.Limits,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ planmodifier.String = retainStateWhenUnsetModifier{}
+
+// retainStateWhenUnsetModifier plans the prior state value when the attribute is removed from
+// the configuration. It is meant for optional and computed attributes that the API can set,
+// but never clear: omitting the attribute from an update leaves the current value in place,
+// so planning null would always produce an inconsistent result after apply.
+type retainStateWhenUnsetModifier struct{}
+
+// retainStateWhenUnset returns a plan modifier that keeps the prior state value when the
+// configuration value is null, and plans null when there is no prior value.
+func retainStateWhenUnset() planmodifier.String {
+	return retainStateWhenUnsetModifier{}
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m retainStateWhenUnsetModifier) Description(_ context.Context) string {
+	return "Once set, the value cannot be cleared by removing it from the configuration; the current value is retained."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m retainStateWhenUnsetModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m retainStateWhenUnsetModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Configured values are always planned as-is
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	// Without a prior value there is nothing to retain, so the attribute stays unset
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
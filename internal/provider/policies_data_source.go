@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PoliciesDataSource{}
+
+// NewPoliciesDataSource creates a new PoliciesDataSource.
+func NewPoliciesDataSource() datasource.DataSource {
+	return &PoliciesDataSource{}
+}
+
+// PoliciesDataSource defines the data source implementation.
+type PoliciesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// PoliciesDataSourceModel describes the data source data model.
+type PoliciesDataSourceModel struct {
+	NamespaceID types.String             `tfsdk:"namespace_id"`
+	NamePrefix  types.String             `tfsdk:"name_prefix"`
+	Policies    []PolicySummaryDataModel `tfsdk:"policies"`
+}
+
+// PolicySummaryDataModel describes a single policy returned by the PoliciesDataSource.
+type PolicySummaryDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Enforced    types.Bool   `tfsdk:"enforced"`
+	RouteCount  types.Int64  `tfsdk:"route_count"`
+}
+
+// Metadata sets the data source type name for the PoliciesDataSource.
+func (d *PoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policies"
+}
+
+// Schema defines the structure and attributes of the PoliciesDataSource.
+func (d *PoliciesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the policies in the organization or in a namespace, e.g. for reports or to attach policies to routes dynamically.",
+		Attributes: map[string]schema.Attribute{
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace to list the policies of, including its child namespaces. " +
+					"When not set, the policies of the whole organization are listed.",
+				Optional: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list the policies whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"policies": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching policies, sorted as returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the policy.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the policy.",
+							Computed:            true,
+						},
+						"namespace_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the namespace the policy belongs to.",
+							Computed:            true,
+						},
+						"enforced": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is enforced on all routes in its namespace.",
+							Computed:            true,
+						},
+						"route_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of routes the policy is attached to.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the PoliciesDataSource.
+func (d *PoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the policies matching the filters.
+func (d *PoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policiesURL := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, d.organizationID)
+	if !data.NamespaceID.IsNull() {
+		policiesURL += "?namespaceId=" + url.QueryEscape(data.NamespaceID.ValueString()) + "&includeDescendants=true"
+	}
+
+	var policies []Policy
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", policiesURL, nil, http.StatusOK, &policies); err != nil {
+		resp.Diagnostics.AddError("Error fetching policies", err.Error())
+		return
+	}
+
+	data.Policies = []PolicySummaryDataModel{}
+	for _, policy := range policies {
+		if !strings.HasPrefix(policy.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+		data.Policies = append(data.Policies, PolicySummaryDataModel{
+			ID:          types.StringValue(policy.ID),
+			Name:        types.StringValue(policy.Name),
+			NamespaceID: types.StringValue(policy.NamespaceID),
+			Enforced:    types.BoolValue(policy.Enforced),
+			RouteCount:  types.Int64Value(int64(len(policy.Routes))),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -1,14 +1,17 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -25,9 +28,21 @@ type PolicyDataSource struct {
 
 // PolicyDataSourceModel describes the data source data model.
 type PolicyDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	NamespaceID types.String `tfsdk:"namespace_id"`
+	ID          types.String                 `tfsdk:"id"`
+	Name        types.String                 `tfsdk:"name"`
+	NamespaceID types.String                 `tfsdk:"namespace_id"`
+	Description types.String                 `tfsdk:"description"`
+	Enforced    types.Bool                   `tfsdk:"enforced"`
+	Explanation types.String                 `tfsdk:"explanation"`
+	PPL         types.String                 `tfsdk:"ppl"`
+	Remediation types.String                 `tfsdk:"remediation"`
+	Routes      []PolicyRouteDataSourceModel `tfsdk:"routes"`
+}
+
+// PolicyRouteDataSourceModel describes a route the policy is attached to.
+type PolicyRouteDataSourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
 }
 
 func NewPolicyDataSource() datasource.DataSource {
@@ -40,7 +55,7 @@ func (d *PolicyDataSource) Metadata(_ context.Context, req datasource.MetadataRe
 
 func (d *PolicyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetch a policy by name.",
+		Description: "Fetch a policy by name, including its PPL and the routes it is attached to.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -54,6 +69,42 @@ func (d *PolicyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Computed:            true,
 				MarkdownDescription: "ID of the policy",
 			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Description of the policy",
+			},
+			"enforced": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the policy is enforced on all routes in its namespace",
+			},
+			"explanation": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Explanation shown to users who are denied access by the policy",
+			},
+			"ppl": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Pomerium Policy Language (PPL) definition of the policy, as compact JSON",
+			},
+			"remediation": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Remediation shown to users who are denied access by the policy",
+			},
+			"routes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Routes the policy is attached to",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the route",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the route",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -72,9 +123,7 @@ func (d *PolicyDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	d.client = provider.client
-	d.token = provider.token
-	d.organizationID = provider.organizationID
+	d.client, d.token, d.organizationID = provider.apiCredentials()
 }
 
 func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -92,21 +141,56 @@ func (d *PolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	// Find the policy by name
-	var foundPolicy *Policy
+	var matches []Policy
 	for _, policy := range policies {
 		if policy.Name == data.Name.ValueString() {
-			foundPolicy = &policy
-			break
+			matches = append(matches, policy)
 		}
 	}
 
-	if foundPolicy == nil {
+	if len(matches) == 0 {
 		resp.Diagnostics.AddError("Policy Not Found", fmt.Sprintf("No policy found with name: %s", data.Name.ValueString()))
 		return
 	}
 
-	// Set the ID in the data model
+	// The API allows duplicate names, in which case the lookup is ambiguous
+	if len(matches) > 1 {
+		candidates := make([]string, 0, len(matches))
+		for _, policy := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s (namespace %s)", policy.ID, policy.NamespaceID))
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Multiple Policies Found",
+			fmt.Sprintf("%d policies are named %q: %s. Rename the duplicates so that the name is unique.",
+				len(matches), data.Name.ValueString(), strings.Join(candidates, ", ")),
+		)
+		return
+	}
+	foundPolicy := &matches[0]
+
+	// Map the policy to the data model
+	ppl := bytes.Buffer{}
+	if len(foundPolicy.PPL) > 0 {
+		if err := json.Compact(&ppl, foundPolicy.PPL); err != nil {
+			resp.Diagnostics.AddError("Error parsing policy PPL", err.Error())
+			return
+		}
+	}
+
 	data.ID = types.StringValue(foundPolicy.ID)
+	data.Description = types.StringValue(stringOrEmpty(foundPolicy.Description))
+	data.Enforced = types.BoolValue(foundPolicy.Enforced)
+	data.Explanation = types.StringValue(stringOrEmpty(foundPolicy.Explanation))
+	data.PPL = types.StringValue(ppl.String())
+	data.Remediation = types.StringValue(stringOrEmpty(foundPolicy.Remediation))
+	data.Routes = []PolicyRouteDataSourceModel{}
+	for _, route := range foundPolicy.Routes {
+		data.Routes = append(data.Routes, PolicyRouteDataSourceModel{
+			ID:   types.StringValue(route.ID),
+			Name: types.StringValue(route.Name),
+		})
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
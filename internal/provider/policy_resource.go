@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,12 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PolicyResource{}
 var _ resource.ResourceWithImportState = &PolicyResource{}
+var _ resource.ResourceWithModifyPlan = &PolicyResource{}
 
 // NewPolicyResource creates a new PolicyResource.
 func NewPolicyResource() resource.Resource {
@@ -34,14 +37,17 @@ type PolicyResource struct {
 
 // PolicyResourceModel describes the resource data model.
 type PolicyResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Enforced    types.Bool   `tfsdk:"enforced"`
-	Explanation types.String `tfsdk:"explanation"`
-	NamespaceID types.String `tfsdk:"namespace_id"`
-	PPL         types.String `tfsdk:"ppl"`
-	Remediation types.String `tfsdk:"remediation"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	Enforced           types.Bool   `tfsdk:"enforced"`
+	Explanation        types.String `tfsdk:"explanation"`
+	NamespaceID        types.String `tfsdk:"namespace_id"`
+	PPL                types.String `tfsdk:"ppl"`
+	Remediation        types.String `tfsdk:"remediation"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	WaitForDeployment  types.Bool   `tfsdk:"wait_for_deployment"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
 }
 
 // Metadata sets the resource type name for the PolicyResource.
@@ -86,17 +92,29 @@ func (r *PolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"namespace_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the namespace this policy belongs to.",
+				Validators: []validator.String{
+					stringIsID(),
+				},
 			},
 			// PPL is a required attribute containing the Pomerium Policy Language definition
 			"ppl": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The Pomerium Policy Language (PPL) definition for this policy.",
+				Validators: []validator.String{
+					stringIsPPL(),
+				},
 			},
 			// Remediation is a required attribute providing guidance on addressing policy violations
 			"remediation": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Instructions for remediating policy violations.",
 			},
+			// DeletionProtection is an optional attribute guarding the policy against deletion
+			"deletion_protection": deletionProtectionAttribute("policy"),
+			// WaitForDeployment is an optional attribute making changes wait until they are deployed
+			"wait_for_deployment": waitForDeploymentAttribute("policy"),
+			// AdoptExisting is an optional attribute taking an existing policy under management on creation
+			"adopt_existing": adoptExistingAttribute("policy", "the same name"),
 		},
 	}
 }
@@ -120,9 +138,53 @@ func (r *PolicyResource) Configure(ctx context.Context, req resource.ConfigureRe
 	}
 
 	// Set the provider data as the ResourceData
-	r.client = provider.client
-	r.token = provider.token
-	r.organizationID = provider.organizationID
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// ModifyPlan warns when another policy in the namespace already has the planned name. The API allows
+// duplicate names, but they make the name-based pomeriumzero_policy data source ambiguous.
+func (r *PolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan PolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Name.IsUnknown() || plan.NamespaceID.IsUnknown() {
+		return
+	}
+
+	// A policy created with adopt_existing takes over the policy with the same name
+	if req.State.Raw.IsNull() && plan.AdoptExisting.ValueBool() {
+		return
+	}
+
+	policies, err := r.listPolicies(ctx)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to list policies to check for duplicate names: %s", err)
+		return
+	}
+
+	var duplicateIDs []string
+	for _, policy := range policies {
+		if policy.Name == plan.Name.ValueString() && policy.NamespaceID == plan.NamespaceID.ValueString() && policy.ID != plan.ID.ValueString() {
+			duplicateIDs = append(duplicateIDs, policy.ID)
+		}
+	}
+
+	if len(duplicateIDs) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("name"),
+			"Duplicate Policy Name",
+			fmt.Sprintf("Other policies in the namespace are already named %q: %s. "+
+				"Looking up this policy by name with the pomeriumzero_policy data source will fail until the names are unique.",
+				plan.Name.ValueString(), strings.Join(duplicateIDs, ", ")),
+		)
+	}
 }
 
 // Create creates a new policy in Pomerium Zero.
@@ -144,9 +206,12 @@ func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Call the API to create the policy
 	policy, err := r.createPolicy(ctx, policyReq)
+	if err != nil && isConflict(err) && plan.AdoptExisting.ValueBool() {
+		policy, err = r.adoptPolicy(ctx, plan, err)
+	}
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error creating policy", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error creating policy", err, req.Plan)...)
 		return
 	}
 
@@ -158,6 +223,9 @@ func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Append any diagnostics from setting the state
 	resp.Diagnostics.Append(diags...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, inNamespace(plan.NamespaceID.ValueString()))...)
 }
 
 // Read retrieves the current state of a PolicyResource from the API
@@ -173,15 +241,15 @@ func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	// Fetch the policy from the API using its ID
-	policy, err := r.getPolicy(ctx, state.ID.ValueString())
+	policy, err := r.getPolicyFromList(ctx, state.ID.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "policy not found") {
+		if errors.Is(err, errNotFound) {
 			// If the policy is not found in the API, remove it from Terraform state
 			resp.State.RemoveResource(ctx)
 			return
 		}
 		// If there's any other error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error reading policy", err.Error())
+		resp.Diagnostics.Append(errorDiagnostics("Error reading policy", err.Error(), err)...)
 		return
 	}
 
@@ -230,7 +298,7 @@ func (r *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	policy, err := r.updatePolicy(ctx, policyID, policyReq)
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError("Error updating policy", err.Error())
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error updating policy", err, req.Plan)...)
 		return
 	}
 
@@ -239,6 +307,9 @@ func (r *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Set the updated plan as the new state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, inNamespace(plan.NamespaceID.ValueString()))...)
 }
 
 // Delete handles the deletion of a PolicyResource
@@ -257,16 +328,23 @@ func (r *PolicyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Refuse to delete protected policies
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, "policy", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Call the deletePolicy method to remove the policy from the API
 	err := r.deletePolicy(ctx, state.ID.ValueString())
 
 	// If there's an error during deletion, add it to the diagnostics
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting policy", err.Error())
+		resp.Diagnostics.Append(errorDiagnostics("Error deleting policy", err.Error(), err)...)
 		return
 	}
 
-	// If we reach here, the policy was successfully deleted
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, state.WaitForDeployment, inNamespace(state.NamespaceID.ValueString()))...)
 }
 
 // ImportState handles the import of an existing policy into Terraform state
@@ -289,7 +367,7 @@ func (r *PolicyResource) ImportState(ctx context.Context, req resource.ImportSta
 	}
 
 	// Create a new PolicyResourceModel to hold the imported state
-	var state PolicyResourceModel
+	state := PolicyResourceModel{DeletionProtection: types.BoolValue(false)}
 	// Populate the state with the fetched policy data
 	updatePolicyResourceModel(&state, policy)
 
@@ -304,6 +382,9 @@ func (r *PolicyResource) ImportState(ctx context.Context, req resource.ImportSta
 
 // createPolicy creates a new policy in Pomerium Zero
 func (r *PolicyResource) createPolicy(ctx context.Context, policy CreatePolicyRequest) (*Policy, error) {
+	// Retries of the request must not create duplicate policies
+	ctx = withIdempotencyKey(ctx)
+
 	// Construct the URL for the API endpoint
 	url := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, r.organizationID)
 	body, err := json.Marshal(policy)
@@ -311,7 +392,7 @@ func (r *PolicyResource) createPolicy(ctx context.Context, policy CreatePolicyRe
 		return nil, fmt.Errorf("error marshaling policy: %w", err)
 	}
 
-	log.Printf("[DEBUG] Create policy request body: %s", string(body))
+	log.Printf("[DEBUG] Create policy request body: %s", redactJSON(body))
 
 	// Create a new HTTP POST request with the given context
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
@@ -333,10 +414,10 @@ func (r *PolicyResource) createPolicy(ctx context.Context, policy CreatePolicyRe
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	log.Printf("[DEBUG] Create policy response status: %d, body: %s", resp.StatusCode, string(responseBody))
+	log.Printf("[DEBUG] Create policy response status: %d, body: %s", resp.StatusCode, redactJSON(responseBody))
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp.StatusCode, responseBody)
 	}
 
 	var createdPolicy Policy
@@ -347,6 +428,27 @@ func (r *PolicyResource) createPolicy(ctx context.Context, policy CreatePolicyRe
 	return &createdPolicy, nil
 }
 
+// getPolicyFromList looks the policy up in the list of all policies, which is cached for the duration
+// of the operation, so that refreshing many policies lists them once. Policies missing from the list
+// are read individually, which also reports policies that no longer exist.
+func (r *PolicyResource) getPolicyFromList(ctx context.Context, policyID string) (*Policy, error) {
+	url := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, r.organizationID)
+
+	var policies []Policy
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &policies); err != nil {
+		log.Printf("[DEBUG] Could not list policies, reading policy %s individually: %s", policyID, err)
+		return r.getPolicy(ctx, policyID)
+	}
+
+	for i := range policies {
+		if policies[i].ID == policyID {
+			return &policies[i], nil
+		}
+	}
+
+	return r.getPolicy(ctx, policyID)
+}
+
 // getPolicy retrieves a policy from Pomerium Zero by its ID
 func (r *PolicyResource) getPolicy(ctx context.Context, policyID string) (*Policy, error) {
 	// Construct the URL for the API endpoint
@@ -368,6 +470,10 @@ func (r *PolicyResource) getPolicy(ctx context.Context, policyID string) (*Polic
 	}
 	defer resp.Body.Close()
 
+	if isNotFoundStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("GET %s: %w", url, errNotFound)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
@@ -414,12 +520,12 @@ func (r *PolicyResource) updatePolicy(ctx context.Context, policyID string, poli
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("policy with ID %s not found. It may have been deleted outside of Terraform", policyID)
+	if isNotFoundStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("policy with ID %s: %w. It may have been deleted outside of Terraform", policyID, errNotFound)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp.StatusCode, responseBody)
 	}
 
 	var updatedPolicy Policy
@@ -581,6 +687,27 @@ func (r *PolicyResource) GetSchemaResourceData(ctx context.Context) ([]byte, err
 	return data, nil
 }
 
+// adoptPolicy takes the policy conflicting with the planned one under management, i.e. the policy of the
+// namespace with the same name, and updates it to match the plan. The conflict is returned when no
+// such policy exists.
+func (r *PolicyResource) adoptPolicy(ctx context.Context, plan PolicyResourceModel, conflict error) (*Policy, error) {
+	policies, err := r.listPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing policies to adopt the existing policy: %w", err)
+	}
+
+	for _, policy := range policies {
+		if policy.NamespaceID != plan.NamespaceID.ValueString() || policy.Name != plan.Name.ValueString() {
+			continue
+		}
+
+		log.Printf("[INFO] Adopting existing policy %s", policy.ID)
+		return r.updatePolicy(ctx, policy.ID, updatePolicyRequest(plan))
+	}
+
+	return nil, conflict
+}
+
 // listPolicies retrieves all policies from the Pomerium Zero API
 func (r *PolicyResource) listPolicies(ctx context.Context) ([]*Policy, error) {
 	// Construct the URL for the API endpoint
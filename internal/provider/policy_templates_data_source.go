@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PolicyTemplatesDataSource{}
+
+// NewPolicyTemplatesDataSource creates a new PolicyTemplatesDataSource.
+func NewPolicyTemplatesDataSource() datasource.DataSource {
+	return &PolicyTemplatesDataSource{}
+}
+
+// PolicyTemplatesDataSource defines the data source implementation.
+type PolicyTemplatesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// PolicyTemplatesDataSourceModel describes the data source data model.
+type PolicyTemplatesDataSourceModel struct {
+	Name      types.String              `tfsdk:"name"`
+	Templates []PolicyTemplateDataModel `tfsdk:"templates"`
+}
+
+// PolicyTemplateDataModel describes a single policy template returned by the PolicyTemplatesDataSource.
+type PolicyTemplateDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	PPL         types.String `tfsdk:"ppl"`
+}
+
+// Metadata sets the data source type name for the PolicyTemplatesDataSource.
+func (d *PolicyTemplatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_templates"
+}
+
+// Schema defines the structure and attributes of the PolicyTemplatesDataSource.
+func (d *PolicyTemplatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the built-in policy templates of the Pomerium Zero console, " +
+			"so policies can be created from a template instead of copying its PPL.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Only list the template with this name.",
+				Optional:            true,
+			},
+			"templates": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching policy templates.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the template.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the template.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "A description of the template.",
+							Computed:            true,
+						},
+						"ppl": schema.StringAttribute{
+							MarkdownDescription: "The Pomerium Policy Language (PPL) definition of the template, as compact JSON. " +
+								"Can be used as the `ppl` of a `pomeriumzero_policy` resource.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the PolicyTemplatesDataSource.
+func (d *PolicyTemplatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the policy templates.
+func (d *PolicyTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PolicyTemplatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/policy-templates", apiBaseURL, d.organizationID)
+
+	var templates []PolicyTemplate
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &templates); err != nil {
+		resp.Diagnostics.AddError("Error fetching policy templates", err.Error())
+		return
+	}
+
+	data.Templates = []PolicyTemplateDataModel{}
+	for _, template := range templates {
+		if !data.Name.IsNull() && template.Name != data.Name.ValueString() {
+			continue
+		}
+
+		ppl := bytes.Buffer{}
+		if err := json.Compact(&ppl, template.PPL); err != nil {
+			resp.Diagnostics.AddError("Error parsing policy template PPL", fmt.Sprintf("Template %s: %s", template.Name, err))
+			return
+		}
+
+		data.Templates = append(data.Templates, PolicyTemplateDataModel{
+			ID:          types.StringValue(template.ID),
+			Name:        types.StringValue(template.Name),
+			Description: stringValueOrNull(template.Description),
+			PPL:         types.StringValue(ppl.String()),
+		})
+	}
+
+	if !data.Name.IsNull() && len(data.Templates) == 0 {
+		resp.Diagnostics.AddError("Policy Template Not Found", fmt.Sprintf("No policy template found with name: %s", data.Name.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
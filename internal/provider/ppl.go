@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// pplActions lists the actions of a PPL rule.
+	pplActions = []string{"allow", "deny"}
+
+	// pplOperators lists the logical operators of a PPL rule.
+	pplOperators = []string{"and", "or", "not", "nor"}
+
+	// pplCriteria lists the criteria supported by PPL. Criteria such as claim take a sub-path, e.g. claim/groups.
+	pplCriteria = []string{
+		"accept", "authenticated_user", "claim", "client_certificate", "cors_preflight", "date", "day_of_week",
+		"device", "domain", "email", "groups", "http_method", "http_path", "invalid_client_certificate",
+		"pomerium_routes", "record", "reject", "source_ip", "time_of_day", "user",
+	}
+)
+
+// parsePPL decodes a JSON PPL document and validates its structure.
+func parsePPL(document string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var ppl interface{}
+	if err := decoder.Decode(&ppl); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the document")
+	}
+
+	if err := validatePPL(ppl); err != nil {
+		return nil, err
+	}
+	return ppl, nil
+}
+
+// validatePPL validates the structure of a decoded PPL document: a rule or a list of rules.
+func validatePPL(ppl interface{}) error {
+	switch ppl := ppl.(type) {
+	case map[string]interface{}:
+		return validatePPLRule(ppl, "$")
+	case []interface{}:
+		if len(ppl) == 0 {
+			return fmt.Errorf("$: a PPL document must contain at least one rule")
+		}
+		for i, rule := range ppl {
+			rule, ok := rule.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("$[%d]: a rule must be an object", i)
+			}
+			if err := validatePPLRule(rule, fmt.Sprintf("$[%d]", i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("$: a PPL document must be a rule or a list of rules")
+	}
+}
+
+// validatePPLRule validates a rule, which maps actions to logical operators.
+func validatePPLRule(rule map[string]interface{}, path string) error {
+	if len(rule) == 0 {
+		return fmt.Errorf("%s: a rule must contain an allow or deny action", path)
+	}
+	for _, action := range sortedKeys(rule) {
+		actionPath := path + "." + action
+		if !containsString(pplActions, action) {
+			return fmt.Errorf("%s: unknown action, expected one of %s", actionPath, strings.Join(pplActions, ", "))
+		}
+		operators, ok := rule[action].(map[string]interface{})
+		if !ok || len(operators) == 0 {
+			return fmt.Errorf("%s: an action must be an object of logical operators", actionPath)
+		}
+		if err := validatePPLOperators(operators, actionPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePPLOperators validates logical operators, which map to lists of criteria.
+func validatePPLOperators(operators map[string]interface{}, path string) error {
+	for _, operator := range sortedKeys(operators) {
+		operatorPath := path + "." + operator
+		if !containsString(pplOperators, operator) {
+			return fmt.Errorf("%s: unknown logical operator, expected one of %s", operatorPath, strings.Join(pplOperators, ", "))
+		}
+		criteria, ok := operators[operator].([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: a logical operator must be a list of criteria", operatorPath)
+		}
+		for i, criterion := range criteria {
+			if err := validatePPLCriterion(criterion, fmt.Sprintf("%s[%d]", operatorPath, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validatePPLCriterion validates a criterion, which is either a single criterion or nested logical operators.
+func validatePPLCriterion(criterion interface{}, path string) error {
+	object, ok := criterion.(map[string]interface{})
+	if !ok || len(object) == 0 {
+		return fmt.Errorf("%s: a criterion must be an object", path)
+	}
+
+	// Nested logical operators, e.g. {"or": [...]} within an "and"
+	for key := range object {
+		if containsString(pplOperators, key) {
+			return validatePPLOperators(object, path)
+		}
+	}
+
+	if len(object) != 1 {
+		return fmt.Errorf("%s: a criterion must contain exactly one key", path)
+	}
+	for key := range object {
+		name, _, _ := strings.Cut(key, "/")
+		if !containsString(pplCriteria, name) {
+			return fmt.Errorf("%s.%s: unknown criterion", path, key)
+		}
+	}
+	return nil
+}
+
+// encodePPL encodes a decoded PPL document as canonical JSON, with sorted object keys and without whitespace.
+func encodePPL(ppl interface{}) (string, error) {
+	result, err := json.Marshal(ppl)
+	if err != nil {
+		return "", fmt.Errorf("error encoding PPL: %w", err)
+	}
+	return string(result), nil
+}
+
+// sortedKeys returns the keys of a map in a stable order, so errors are reported deterministically.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PPLBuilderFunction{}
+
+// NewAllowEmailsFunction creates a function building a policy that allows users by email address.
+func NewAllowEmailsFunction() function.Function {
+	return &PPLBuilderFunction{
+		name:        "allow_emails",
+		parameter:   "emails",
+		description: "email addresses",
+		criterion:   "email",
+		operator:    "is",
+	}
+}
+
+// NewAllowDomainsFunction creates a function building a policy that allows users by the domain of their email address.
+func NewAllowDomainsFunction() function.Function {
+	return &PPLBuilderFunction{
+		name:        "allow_domains",
+		parameter:   "domains",
+		description: "email domains",
+		criterion:   "domain",
+		operator:    "is",
+	}
+}
+
+// NewAllowGroupsFunction creates a function building a policy that allows users by group membership.
+func NewAllowGroupsFunction() function.Function {
+	return &PPLBuilderFunction{
+		name:        "allow_groups",
+		parameter:   "groups",
+		description: "groups",
+		criterion:   "groups",
+		operator:    "has",
+	}
+}
+
+// PPLBuilderFunction builds a PPL document that allows access when a single criterion matches any of the given values.
+type PPLBuilderFunction struct {
+	name        string
+	parameter   string
+	description string
+	criterion   string
+	operator    string
+}
+
+// Metadata sets the name of the PPLBuilderFunction.
+func (f *PPLBuilderFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+// Definition defines the parameters and return type of the PPLBuilderFunction.
+func (f *PPLBuilderFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: fmt.Sprintf("Builds a PPL document allowing the given %s", f.description),
+		MarkdownDescription: fmt.Sprintf("Builds the JSON of a Pomerium Policy Language (PPL) document that allows access "+
+			"when the `%s` criterion `%s` any of the given %s, for the `ppl` attribute of `pomeriumzero_policy`.",
+			f.criterion, f.operator, f.description),
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                f.parameter,
+				ElementType:         types.StringType,
+				MarkdownDescription: fmt.Sprintf("The %s to allow. Must not be empty.", f.description),
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the PPL document.
+func (f *PPLBuilderFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var values []string
+	resp.Error = req.Arguments.Get(ctx, &values)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(values) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("At least one of the %s must be given.", f.description))
+		return
+	}
+
+	rules := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The %s must not be empty strings.", f.description))
+			return
+		}
+		rules = append(rules, map[string]interface{}{
+			f.criterion: map[string]interface{}{f.operator: value},
+		})
+	}
+
+	ppl, err := json.Marshal(map[string]interface{}{
+		"allow": map[string]interface{}{"or": rules},
+	})
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Error encoding PPL: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(ppl))
+}
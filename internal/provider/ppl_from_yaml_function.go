@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PPLFromYAMLFunction{}
+
+// NewPPLFromYAMLFunction creates a new PPLFromYAMLFunction.
+func NewPPLFromYAMLFunction() function.Function {
+	return &PPLFromYAMLFunction{}
+}
+
+// PPLFromYAMLFunction converts a YAML PPL document to JSON.
+type PPLFromYAMLFunction struct{}
+
+// Metadata sets the name of the PPLFromYAMLFunction.
+func (f *PPLFromYAMLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ppl_from_yaml"
+}
+
+// Definition defines the parameters and return type of the PPLFromYAMLFunction.
+func (f *PPLFromYAMLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a YAML PPL document to JSON",
+		MarkdownDescription: "Converts a Pomerium Policy Language (PPL) document written in YAML, as found in the Pomerium documentation, " +
+			"to the canonical JSON expected by the `ppl` attribute of `pomeriumzero_policy`. Object keys are sorted, " +
+			"so the result only changes when the policy changes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "yaml",
+				MarkdownDescription: "The YAML PPL document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the YAML document.
+func (f *PPLFromYAMLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	ppl, err := decodeYAML([]byte(document))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid YAML: %s", err))
+		return
+	}
+
+	switch ppl.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		resp.Error = function.NewArgumentFuncError(0, "A PPL document must be a mapping or a sequence of rules.")
+		return
+	}
+
+	result, err := json.Marshal(ppl)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Error encoding PPL: %s", err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(result))
+}
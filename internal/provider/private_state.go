@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// privateStateVersionKey is the private state key holding the version of an object last seen by Terraform.
+const privateStateVersionKey = "version"
+
+// privateStateGetter is implemented by the private state of requests.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is implemented by the private state of responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// objectVersion identifies the version of an object last seen by Terraform, for optimistic locking.
+// It is kept in the private state of the resource, which Terraform stores but does not show to users.
+type objectVersion struct {
+	ETag string `json:"etag,omitempty"`
+}
+
+// getObjectVersion returns the version of the object stored in the private state, which is empty when
+// none was stored, e.g. for resources created by earlier versions of the provider.
+func getObjectVersion(ctx context.Context, private privateStateGetter) (objectVersion, diag.Diagnostics) {
+	var version objectVersion
+	value, diags := private.GetKey(ctx, privateStateVersionKey)
+	if diags.HasError() || len(value) == 0 {
+		return version, diags
+	}
+
+	if err := json.Unmarshal(value, &version); err != nil {
+		diags.AddError("Invalid Private State", "Unable to decode the version of the object: "+err.Error())
+	}
+	return version, diags
+}
+
+// setObjectVersion stores the version of the object in the private state.
+func setObjectVersion(ctx context.Context, private privateStateSetter, version objectVersion) diag.Diagnostics {
+	value, err := json.Marshal(version)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid Private State", "Unable to encode the version of the object: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, privateStateVersionKey, value)
+}
@@ -5,20 +5,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const (
-	// Base URL for version 0 of the Pomerium Zero API
-	apiBaseURL = "https://console.pomerium.app/api/v0"
+	// Default URL of the Pomerium Zero API, which api_url overrides
+	consoleURL = "https://console.pomerium.app"
+	// Base URL for the version of the Pomerium Zero API the provider is written against
+	apiBaseURL = consoleURL + "/api/" + apiVersion
 	// Endpoint exhanging the API token for a JWT
 	tokenEndpoint = apiBaseURL + "/token"
 	// Endpoint for retrieving organization information
@@ -27,7 +37,9 @@ const (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &pomeriumZeroProvider{}
+	_ provider.Provider                       = &pomeriumZeroProvider{}
+	_ provider.ProviderWithEphemeralResources = &pomeriumZeroProvider{}
+	_ provider.ProviderWithFunctions          = &pomeriumZeroProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -45,15 +57,48 @@ type pomeriumZeroProvider struct {
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
-	version        string
+	version string
+
+	// mu guards the fields below, which are set by Configure and read concurrently by the
+	// resources and data sources that Terraform configures and runs in parallel.
+	mu             sync.RWMutex
 	client         *http.Client
 	token          string
 	organizationID string
+	// routeDefaults holds the defaults for unset route attributes, or nil when not configured
+	routeDefaults *routeDefaultsModel
+}
+
+// apiCredentials returns the HTTP client, the bearer token and the organization ID used to call the API.
+func (p *pomeriumZeroProvider) apiCredentials() (*http.Client, string, string) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.client, p.token, p.organizationID
+}
+
+// routeDefaultsConfig returns the route_defaults block of the provider, or nil when it is not configured.
+func (p *pomeriumZeroProvider) routeDefaultsConfig() *routeDefaultsModel {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.routeDefaults
 }
 
 // pomeriumZeroProviderModel describes the provider data model.
 type pomeriumZeroProviderModel struct {
-	APIToken types.String `tfsdk:"api_token"`
+	APIToken         types.String        `tfsdk:"api_token"`
+	APITokenFile     types.String        `tfsdk:"api_token_file"`
+	OIDC             *oidcModel          `tfsdk:"oidc"`
+	APIURL           types.String        `tfsdk:"api_url"`
+	APIVersion       types.String        `tfsdk:"api_version"`
+	RequestTimeout   types.String        `tfsdk:"request_timeout"`
+	IdleConnTimeout  types.String        `tfsdk:"idle_connection_timeout"`
+	MaxIdleConns     types.Int64         `tfsdk:"max_idle_connections"`
+	CACertPEM        types.String        `tfsdk:"ca_cert_pem"`
+	CACertFile       types.String        `tfsdk:"ca_cert_file"`
+	OrganizationID   types.String        `tfsdk:"organization_id"`
+	OrganizationName types.String        `tfsdk:"organization_name"`
+	ReadOnly         types.Bool          `tfsdk:"read_only"`
+	RouteDefaults    *routeDefaultsModel `tfsdk:"route_defaults"`
 }
 
 // Metadata returns the provider type name.
@@ -68,10 +113,97 @@ func (p *pomeriumZeroProvider) Schema(_ context.Context, _ provider.SchemaReques
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"api_token": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "The API token for authenticating with Pomerium Zero",
+				Optional:  true,
+				Sensitive: true,
+				Description: "The API token for authenticating with Pomerium Zero. " +
+					"Accepts ephemeral values, such as a secret read with an ephemeral resource, which are never stored in plans or state. " +
+					"When the token is only known during apply, authentication is deferred until then. " +
+					"Exactly one of `api_token`, `api_token_file` and `oidc` must be set.",
+			},
+			"api_token_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a file holding the API token for authenticating with Pomerium Zero, " +
+					"e.g. a secret mounted by a CI system. Surrounding whitespace is ignored. " +
+					"Exactly one of `api_token`, `api_token_file` and `oidc` must be set.",
+			},
+			"api_url": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("The URL of the Pomerium Zero API, e.g. of a staging environment or a mock server for testing. "+
+					"All endpoints, including those exchanging the API token, are served below it. "+
+					"Defaults to the `%s` environment variable, or `%s` when it is not set.", apiURLEnvVar, consoleURL),
+				Validators: []validator.String{
+					stringIsURL("http", "https"),
+				},
+			},
+			"api_version": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("The version of the Pomerium Zero API to use. Defaults to `%s`, the version the provider is written against. "+
+					"Supported versions: %s.", apiVersion, strings.Join(supportedAPIVersions(), ", ")),
+				Validators: []validator.String{
+					stringOneOf(supportedAPIVersions()...),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("How long a single attempt of a request to the Pomerium Zero API may take, as a duration such as `30s`. "+
+					"Attempts that time out are retried when that is safe, i.e. for reads, unconditional updates, deletes and creates with an idempotency key. "+
+					"Operations of resources with a `timeouts` block are limited by that block instead. "+
+					"Increase it for slow proxies. Defaults to `%s`.", defaultRequestTimeout),
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"idle_connection_timeout": schema.StringAttribute{
+				Optional: true,
+				Description: fmt.Sprintf("How long idle connections to the Pomerium Zero API are kept open for reuse by later requests, as a duration such as `90s`. "+
+					"This is the idle timeout of HTTP connections, not the interval of TCP keep-alive probes. "+
+					"Set to `0s` to open a new connection for each request. Defaults to `%s`.", defaultIdleConnTimeout),
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"max_idle_connections": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("The number of idle connections to the Pomerium Zero API kept open for reuse by concurrent operations. "+
+					"Defaults to `%d`.", maxIdleConnsPerHost),
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM encoded certificates of certificate authorities to trust in addition to those of the system, " +
+					"e.g. of a TLS-intercepting proxy that requests to the Pomerium Zero API pass through. " +
+					"Conflicts with `ca_cert_file`.",
+				Validators: []validator.String{
+					stringIsPEMCertificates(),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "The path of a file holding PEM encoded certificates of certificate authorities to trust in addition to those of the system. " +
+					"Conflicts with `ca_cert_pem`.",
+			},
+			"organization_id": schema.StringAttribute{
+				Optional: true,
+				Description: "The ID of the organization to manage, for API tokens with access to more than one organization. " +
+					"When neither `organization_id` nor `organization_name` is set, the token must have access to exactly one organization. " +
+					"Conflicts with `organization_name`.",
+				Validators: []validator.String{
+					stringIsID(),
+				},
+			},
+			"organization_name": schema.StringAttribute{
+				Optional: true,
+				Description: "The name of the organization to manage, for API tokens with access to more than one organization. " +
+					"Conflicts with `organization_id`.",
 			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Description: "If set to true, the provider only reads from Pomerium Zero: plans, refreshes and data sources work, " +
+					"but creating, updating or deleting any object fails. Useful for audit pipelines and reporting workspaces.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"oidc":           oidcBlock(),
+			"route_defaults": routeDefaultsBlock(),
 		},
 	}
 }
@@ -85,6 +217,7 @@ func (p *pomeriumZeroProvider) Configure(ctx context.Context, req provider.Confi
 	// Set the provider instance as the ProviderData
 	resp.DataSourceData = p
 	resp.ResourceData = p
+	resp.EphemeralResourceData = p
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
@@ -95,21 +228,135 @@ func (p *pomeriumZeroProvider) Configure(ctx context.Context, req provider.Confi
 
 	// log.Printf("Configuration: API Token: %s, Organization Name: %s", config.APIToken.ValueString(), config.OrganizationName.ValueString())
 
-	if config.APIToken.IsNull() {
+	credentials := 0
+	for _, set := range []bool{!config.APIToken.IsNull(), !config.APITokenFile.IsNull(), config.OIDC != nil} {
+		if set {
+			credentials++
+		}
+	}
+
+	if credentials == 0 {
 		log.Println("API Token is null")
 		resp.Diagnostics.AddError(
 			"Missing API Token Configuration",
-			"The API token is required to authenticate with Pomerium Zero.",
+			"The API token is required to authenticate with Pomerium Zero. Set one of api_token, api_token_file or the oidc block.",
+		)
+		return
+	}
+
+	if credentials > 1 {
+		resp.Diagnostics.AddError(
+			"Conflicting API Token Configuration",
+			"Only one of api_token, api_token_file and the oidc block can be set.",
+		)
+		return
+	}
+
+	if !config.OrganizationID.IsNull() && !config.OrganizationName.IsNull() {
+		resp.Diagnostics.AddError(
+			"Conflicting Organization Configuration",
+			"Only one of organization_id and organization_name can be set.",
 		)
 		return
 	}
 
-	p.client = &http.Client{
-		Timeout: time.Second * 10,
+	// The token can depend on values that are only known during apply, e.g. a secret created in
+	// the same run. Authentication is then deferred, instead of failing the plan.
+	oidcUnknown := config.OIDC != nil && (config.OIDC.Provider.IsUnknown() || config.OIDC.Audience.IsUnknown() || config.OIDC.TokenVariable.IsUnknown())
+	organizationUnknown := config.OrganizationID.IsUnknown() || config.OrganizationName.IsUnknown()
+	if config.APIToken.IsUnknown() || config.APITokenFile.IsUnknown() || oidcUnknown || organizationUnknown {
+		if req.ClientCapabilities.DeferralAllowed {
+			log.Println("API Token is unknown, deferring provider configuration")
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+
+		log.Println("API Token is unknown, deferring authentication to apply")
+		p.mu.Lock()
+		p.client = &http.Client{Transport: unknownCredentialsTransport{}}
+		p.routeDefaults = config.RouteDefaults
+		p.mu.Unlock()
+		return
+	}
+
+	apiToken := config.APIToken.ValueString()
+	if !config.APITokenFile.IsNull() {
+		var err error
+		apiToken, err = readAPITokenFile(config.APITokenFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("api_token_file"), "Unable to Read API Token File", err.Error())
+			return
+		}
 	}
 
-	log.Println("Getting token")
-	token, err := p.getToken(ctx, config.APIToken.ValueString())
+	requestTimeout, settings := connectionSettings(config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	base := httpTransport(settings)
+
+	// Record or replay HTTP fixtures, when enabled through the environment
+	transport, err := fixtureTransportFromEnv(base)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Fixtures Configuration", err.Error())
+		return
+	}
+
+	if transport == nil {
+		transport = base
+	}
+
+	// Send requests to the configured URL of the API
+	apiURL := config.APIURL.ValueString()
+	if config.APIURL.IsNull() {
+		apiURL = os.Getenv(apiURLEnvVar)
+	}
+	transport, err = newAPIURLTransport(transport, apiURL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("api_url"), "Invalid API URL", err.Error())
+		return
+	}
+	if apiURL != "" {
+		log.Printf("Using the Pomerium Zero API at %s", apiURL)
+	}
+
+	// Send requests to the configured version of the API
+	transport, err = newAPIVersionTransport(transport, config.APIVersion.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("api_version"), "Unsupported API Version", err.Error())
+		return
+	}
+
+	// Add rate limiting, retries, metrics, caching, idempotency keys and token renewal
+	tokens := newAPITransport(transport, requestTimeout)
+	transport = tokens
+
+	// Reject all requests that could modify objects in read-only mode
+	if config.ReadOnly.ValueBool() {
+		log.Println("Provider is in read-only mode")
+		transport = &readOnlyTransport{next: transport}
+	}
+
+	// Requests are not limited as a whole, as each of their attempts is limited to the request timeout
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	var token string
+	if config.OIDC != nil {
+		log.Println("Getting OIDC token of the CI job")
+		ciToken, err := config.OIDC.ciToken(ctx)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("oidc"), "Unable to Get OIDC Token", err.Error())
+			return
+		}
+
+		log.Println("Exchanging OIDC token")
+		token, err = getFederatedToken(ctx, client, ciToken)
+	} else {
+		log.Println("Getting token")
+		token, err = getToken(ctx, client, apiToken)
+	}
 	if err != nil {
 		log.Println("Error getting token:", err)
 		resp.Diagnostics.AddError(
@@ -121,29 +368,145 @@ func (p *pomeriumZeroProvider) Configure(ctx context.Context, req provider.Confi
 		return
 	}
 
-	p.token = token
 	log.Println("Token obtained successfully")
 
+	tokens.start(token, func(ctx context.Context) (string, error) {
+		if config.OIDC == nil {
+			return getToken(ctx, client, apiToken)
+		}
+		ciToken, err := config.OIDC.ciToken(ctx)
+		if err != nil {
+			return "", err
+		}
+		return getFederatedToken(ctx, client, ciToken)
+	})
+
 	log.Println("Getting organization ID")
-	orgID, err := p.getOrganizationID(ctx)
+	orgID, err := getOrganizationID(ctx, client, token, config.OrganizationID.ValueString(), config.OrganizationName.ValueString())
 	if err != nil {
 		log.Println("Error getting organization ID:", err)
 
 		resp.Diagnostics.AddError(
 			"Unable to Fetch Organization ID",
 			"An unexpected error occurred when fetching the organization ID. "+
-				"Please check your API token and try again.\n\n"+
+				"Please check your API token and organization configuration and try again.\n\n"+
 				"Error: "+err.Error(),
 		)
 		return
 	}
 
-	p.organizationID = orgID
 	log.Printf("Organization ID obtained successfully: %s", orgID)
+
+	// Publish the configuration at once, so that concurrent readers never see it partially set
+	p.mu.Lock()
+	p.client = client
+	p.token = token
+	p.organizationID = orgID
+	p.routeDefaults = config.RouteDefaults
+	p.mu.Unlock()
+}
+
+// newAPITransport wraps the transport sending requests to the API with the transports that all
+// requests of the provider go through, outermost last. The returned transport renews the bearer
+// token once started with the token obtained in Configure.
+func newAPITransport(next http.RoundTripper, requestTimeout time.Duration) *tokenRefreshTransport {
+	// Slow down as the API rate limit is depleted, instead of failing at the limit
+	var transport http.RoundTripper = newRateLimitTransport(next)
+
+	// Resend requests that failed with a transient error, with exponential backoff
+	transport = &retryTransport{next: transport, attemptTimeout: requestTimeout}
+
+	// Count the requests sent to the API and their retries, for the summary logged when the provider stops
+	transport = &metricsTransport{next: transport}
+
+	// List routes, policies and other collections once per operation, instead of reading objects one by one
+	transport = newListCacheTransport(transport)
+
+	// Send the idempotency key of create operations, so that retried requests never create duplicates
+	transport = &idempotencyKeyTransport{next: transport}
+
+	// Renew the bearer token when it expires during long operations, instead of failing all later requests
+	return &tokenRefreshTransport{next: transport}
+}
+
+// connectionSettings returns the request timeout and the connection settings of the provider
+// configuration, with defaults for those left unset, and the certificate authorities it trusts.
+func connectionSettings(config pomeriumZeroProviderModel, diags *diag.Diagnostics) (time.Duration, httpTransportSettings) {
+	requestTimeout := defaultRequestTimeout
+	settings := defaultHTTPTransportSettings
+
+	if value := config.RequestTimeout.ValueString(); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(path.Root("request_timeout"), "Invalid Request Timeout",
+				fmt.Sprintf("request_timeout must be a positive duration such as \"30s\", got: %q", value))
+		}
+		requestTimeout = timeout
+	}
+
+	if value := config.IdleConnTimeout.ValueString(); value != "" {
+		idleConnTimeout, err := time.ParseDuration(value)
+		if err != nil || idleConnTimeout < 0 {
+			diags.AddAttributeError(path.Root("idle_connection_timeout"), "Invalid Idle Connection Timeout",
+				fmt.Sprintf("idle_connection_timeout must be a duration such as \"90s\", or \"0s\" to close connections after each request, got: %q", value))
+		}
+		settings.idleConnTimeout = idleConnTimeout
+	}
+
+	if !config.MaxIdleConns.IsNull() && !config.MaxIdleConns.IsUnknown() {
+		maxIdleConns := config.MaxIdleConns.ValueInt64()
+		if maxIdleConns < 1 || maxIdleConns > math.MaxInt32 {
+			diags.AddAttributeError(path.Root("max_idle_connections"), "Invalid Maximum Idle Connections",
+				fmt.Sprintf("max_idle_connections must be between 1 and %d, got: %d", math.MaxInt32, maxIdleConns))
+		}
+		settings.maxIdleConns = int(maxIdleConns)
+	}
+
+	if !config.CACertPEM.IsNull() && !config.CACertFile.IsNull() {
+		diags.AddError(
+			"Conflicting CA Certificate Configuration",
+			"Only one of ca_cert_pem and ca_cert_file can be set.",
+		)
+		return requestTimeout, settings
+	}
+
+	bundle, bundlePath := []byte(config.CACertPEM.ValueString()), path.Root("ca_cert_pem")
+	if value := config.CACertFile.ValueString(); value != "" {
+		content, err := os.ReadFile(value)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificate File", err.Error())
+			return requestTimeout, settings
+		}
+		bundle, bundlePath = content, path.Root("ca_cert_file")
+	}
+	if len(bundle) > 0 {
+		pool, err := caCertPool(bundle)
+		if err != nil {
+			diags.AddAttributeError(bundlePath, "Invalid CA Certificates", err.Error())
+		}
+		settings.rootCAs = pool
+	}
+
+	return requestTimeout, settings
+}
+
+// readAPITokenFile returns the API token stored in a file, without surrounding whitespace such as
+// the trailing newline most tools write.
+func readAPITokenFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("error reading API token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("the API token file %s is empty", name)
+	}
+	return token, nil
 }
 
 // Exchange the API token for a JWT bearer token.
-func (p *pomeriumZeroProvider) getToken(ctx context.Context, apiToken string) (string, error) {
+func getToken(ctx context.Context, client *http.Client, apiToken string) (string, error) {
 	payload := strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, apiToken))
 	log.Println("Sending request to token endpoint")
 	req, err := http.NewRequestWithContext(ctx, "POST", tokenEndpoint, payload)
@@ -153,7 +516,7 @@ func (p *pomeriumZeroProvider) getToken(ctx context.Context, apiToken string) (s
 
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := p.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		log.Println("Error making request:", err)
 		return "", err
@@ -177,8 +540,9 @@ func (p *pomeriumZeroProvider) getToken(ctx context.Context, apiToken string) (s
 	return result.IDToken, nil
 }
 
-// Lookup the organization ID by name.
-func (p *pomeriumZeroProvider) getOrganizationID(ctx context.Context) (string, error) {
+// Lookup the ID of the organization to manage, selected by its ID or name, or the only organization
+// the token has access to when neither is set.
+func getOrganizationID(ctx context.Context, client *http.Client, token, id, name string) (string, error) {
 	log.Println("Fetching organization ID")
 
 	req, err := http.NewRequestWithContext(ctx, "GET", organizationsEndpoint, nil)
@@ -187,10 +551,10 @@ func (p *pomeriumZeroProvider) getOrganizationID(ctx context.Context) (string, e
 		return "", err
 	}
 
-	req.Header.Add("Authorization", "Bearer "+p.token)
+	req.Header.Add("Authorization", "Bearer "+token)
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := p.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		log.Println("Error making request:", err)
 		return "", err
@@ -203,7 +567,8 @@ func (p *pomeriumZeroProvider) getOrganizationID(ctx context.Context) (string, e
 	}
 
 	var organizations []struct {
-		ID string `json:"id"`
+		ID   string `json:"id"`
+		Name string `json:"name"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&organizations); err != nil {
@@ -211,28 +576,101 @@ func (p *pomeriumZeroProvider) getOrganizationID(ctx context.Context) (string, e
 		return "", err
 	}
 
-	if len(organizations) != 1 {
-		log.Println("Unexpected number of organizations returned")
-		return "", fmt.Errorf("unexpected number of organizations returned")
+	var matches []string
+	available := make([]string, 0, len(organizations))
+	for _, organization := range organizations {
+		available = append(available, fmt.Sprintf("%s (%s)", organization.Name, organization.ID))
+		if (id == "" && name == "") || (id != "" && organization.ID == id) || (name != "" && organization.Name == name) {
+			matches = append(matches, organization.ID)
+		}
 	}
 
-	return organizations[0].ID, nil
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case id != "":
+		return "", fmt.Errorf("no organization with ID %s is accessible with the API token, available organizations: %s", id, strings.Join(available, ", "))
+	case name != "" && len(matches) == 0:
+		return "", fmt.Errorf("no organization named %q is accessible with the API token, available organizations: %s", name, strings.Join(available, ", "))
+	case name != "":
+		return "", fmt.Errorf("%d organizations are named %q, set organization_id instead: %s", len(matches), name, strings.Join(available, ", "))
+	case len(organizations) == 0:
+		return "", fmt.Errorf("the API token has no access to any organization")
+	default:
+		log.Println("Unexpected number of organizations returned")
+		return "", fmt.Errorf("the API token has access to %d organizations, set organization_id or organization_name to select one: %s",
+			len(organizations), strings.Join(available, ", "))
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *pomeriumZeroProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewActivityLogDataSource,
+		NewCertificatesDataSource,
 		NewClusterDataSource,
+		NewClusterStatusDataSource,
+		NewCurrentUserDataSource,
+		NewDevicesDataSource,
+		NewExportDataSource,
+		NewIdentityProvidersDataSource,
+		NewJWKSDataSource,
+		NewMembersDataSource,
+		NewNamespaceDataSource,
+		NewNamespacesDataSource,
+		NewOrganizationDataSource,
+		NewPoliciesDataSource,
 		NewPolicyDataSource,
+		NewPolicyTemplatesDataSource,
+		NewReleasesDataSource,
+		NewRouteDataSource,
+		NewRoutePoliciesDataSource,
+		NewUsageDataSource,
 	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *pomeriumZeroProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAlertRuleResource,
+		NewAPITokenResource,
+		NewChangesetResource,
 		NewClusterResource,
 		NewClusterSettingsResource,
+		NewConfigSnapshotResource,
+		NewIdpDirectoryProviderResource,
+		NewLogExportResource,
+		NewMetricsExportResource,
+		NewNamespacePermissionResource,
+		NewNotificationWebhookResource,
 		NewPolicyResource,
+		NewRoleAssignmentResource,
 		NewRouteResource,
+		NewRoutesBulkResource,
+		NewSessionRevocationResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *pomeriumZeroProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewClusterBootstrapTokenEphemeralResource,
+		NewConsoleTokenEphemeralResource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in the provider.
+func (p *pomeriumZeroProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewAllowDomainsFunction,
+		NewAllowEmailsFunction,
+		NewAllowGroupsFunction,
+		NewDurationFunction,
+		NewMergePPLFunction,
+		NewNormalizeRouteURLFunction,
+		NewPPLFromYAMLFunction,
+		NewRouteFromYAMLFunction,
+		NewRouteURLFunction,
+		NewValidatePPLFunction,
 	}
 }
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitSlowdownThreshold is the number of remaining requests below which dispatch slows down.
+	rateLimitSlowdownThreshold = 20
+	// maxRateLimitDelay caps how long a single request is held back, so that it is still sent within
+	// the timeout of the HTTP client.
+	maxRateLimitDelay = 5 * time.Second
+	// epochThreshold separates reset headers holding a Unix timestamp from those holding a number of seconds.
+	epochThreshold = 1_000_000_000
+)
+
+// rateLimitTransport spreads requests over the remaining rate limit window, as announced by the
+// rate limit headers of the API responses. While plenty of requests remain, requests are sent
+// immediately. Below rateLimitSlowdownThreshold, requests are spaced out evenly until the window
+// resets, so that large applies complete slowly instead of failing at the limit.
+type rateLimitTransport struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	// remaining is the number of requests left in the current window, or -1 when unknown
+	remaining int
+	// reset is when the current window ends
+	reset time.Time
+	// nextDispatch is the earliest time at which the next request may be sent
+	nextDispatch time.Time
+}
+
+// newRateLimitTransport creates a rateLimitTransport.
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{next: next, remaining: -1}
+}
+
+// RoundTrip waits for the slot of the request, sends it and updates the budget from the response.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if delay := t.reserve(); delay > 0 {
+		// Fail right away when the request could not be sent before the deadline of the operation
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, fmt.Errorf("rate limit delay of %s exceeds the time left for the operation: %w", delay, context.DeadlineExceeded)
+		}
+
+		log.Printf("[DEBUG] Rate limit nearly exhausted, delaying %s %s by %s", req.Method, req.URL.Path, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.update(resp)
+	return resp, nil
+}
+
+// reserve claims a request from the budget and returns how long to wait before sending it.
+func (t *rateLimitTransport) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.remaining < 0 || !now.Before(t.reset) || t.remaining >= rateLimitSlowdownThreshold {
+		if t.remaining > 0 {
+			t.remaining--
+		}
+		return 0
+	}
+
+	// Spread the remaining requests evenly over what is left of the window
+	interval := t.reset.Sub(now) / time.Duration(t.remaining+1)
+	if t.remaining > 0 {
+		t.remaining--
+	}
+
+	dispatch := t.nextDispatch
+	if dispatch.Before(now) {
+		dispatch = now
+	}
+	dispatch = dispatch.Add(interval)
+	t.nextDispatch = dispatch
+
+	delay := dispatch.Sub(now)
+	if delay > maxRateLimitDelay {
+		delay = maxRateLimitDelay
+	}
+	return delay
+}
+
+// update records the budget announced by a response. Responses without rate limit headers leave
+// the budget unchanged, and a 429 response exhausts it until Retry-After, when present.
+func (t *rateLimitTransport) update(resp *http.Response) {
+	now := time.Now()
+	remaining, hasRemaining := headerInt(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	reset, hasReset := headerInt(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset")
+	retryAfter, hasRetryAfter := headerInt(resp.Header, "Retry-After")
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		remaining, hasRemaining = 0, true
+		if hasRetryAfter {
+			reset, hasReset = retryAfter, true
+		}
+	}
+	if !hasRemaining {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.remaining = remaining
+	switch {
+	case !hasReset:
+		// Without a reset time, assume a one minute window
+		t.reset = now.Add(time.Minute)
+	case reset >= epochThreshold:
+		t.reset = time.Unix(int64(reset), 0)
+	default:
+		t.reset = now.Add(time.Duration(reset) * time.Second)
+	}
+
+	if remaining < rateLimitSlowdownThreshold {
+		log.Printf("[DEBUG] %d API requests remaining until %s", remaining, t.reset.Format(time.RFC3339))
+	}
+}
+
+// headerInt returns the value of the first of the named headers holding a non-negative integer.
+func headerInt(header http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		value, err := strconv.Atoi(strings.TrimSpace(header.Get(name)))
+		if err == nil && value >= 0 {
+			return value, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// readOnlyTransport rejects requests that could modify objects in Pomerium Zero, i.e. all requests
+// other than GET and HEAD, except for the exchange of the API token or OIDC token. It implements read_only mode.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip sends the request if it only reads from the API.
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && req.URL.String() != tokenEndpoint && req.URL.String() != federatedTokenEndpoint {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("the provider is configured with read_only = true, which does not allow %s %s. "+
+			"Remove read_only from the provider configuration to create, update or delete objects", req.Method, req.URL.Path)
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ReleasesDataSource{}
+
+// NewReleasesDataSource creates a new ReleasesDataSource.
+func NewReleasesDataSource() datasource.DataSource {
+	return &ReleasesDataSource{}
+}
+
+// ReleasesDataSource defines the data source implementation.
+type ReleasesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// ReleasesDataSourceModel describes the data source data model.
+type ReleasesDataSourceModel struct {
+	Channel       types.String       `tfsdk:"channel"`
+	LatestVersion types.String       `tfsdk:"latest_version"`
+	Releases      []ReleaseDataModel `tfsdk:"releases"`
+}
+
+// ReleaseDataModel describes a single release returned by the ReleasesDataSource.
+type ReleaseDataModel struct {
+	Version    types.String `tfsdk:"version"`
+	Channel    types.String `tfsdk:"channel"`
+	ReleasedAt types.String `tfsdk:"released_at"`
+}
+
+// Metadata sets the data source type name for the ReleasesDataSource.
+func (d *ReleasesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_releases"
+}
+
+// Schema defines the structure and attributes of the ReleasesDataSource.
+func (d *ReleasesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Pomerium versions available to clusters, e.g. to resolve the latest stable version at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"channel": schema.StringAttribute{
+				MarkdownDescription: "Only list the releases of this release channel, e.g. `stable` or `beta`.",
+				Optional:            true,
+			},
+			"latest_version": schema.StringAttribute{
+				MarkdownDescription: "The most recently released version of the matching releases. Not set when no release matches.",
+				Computed:            true,
+			},
+			"releases": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching releases.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The Pomerium version, e.g. `v0.28.0`.",
+							Computed:            true,
+						},
+						"channel": schema.StringAttribute{
+							MarkdownDescription: "The release channel of the version.",
+							Computed:            true,
+						},
+						"released_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp at which the version was released.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the ReleasesDataSource.
+func (d *ReleasesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read lists the releases of the channel and resolves the latest one.
+func (d *ReleasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ReleasesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/releases", apiBaseURL, d.organizationID)
+
+	var releases []Release
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &releases); err != nil {
+		resp.Diagnostics.AddError("Error fetching releases", err.Error())
+		return
+	}
+
+	var latest time.Time
+	data.LatestVersion = types.StringNull()
+	data.Releases = []ReleaseDataModel{}
+	for _, release := range releases {
+		if !data.Channel.IsNull() && release.Channel != data.Channel.ValueString() {
+			continue
+		}
+
+		if releasedAt, err := time.Parse(time.RFC3339, release.ReleasedAt); err == nil && releasedAt.After(latest) {
+			latest = releasedAt
+			data.LatestVersion = types.StringValue(release.Version)
+		}
+
+		data.Releases = append(data.Releases, ReleaseDataModel{
+			Version:    types.StringValue(release.Version),
+			Channel:    types.StringValue(release.Channel),
+			ReleasedAt: stringValueOrNull(release.ReleasedAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRetries is the number of times a request is resent after a transient error.
+	maxRetries = 4
+	// retryBaseDelay is the delay before the first retry, which doubles with every further retry.
+	retryBaseDelay = 500 * time.Millisecond
+	// maxRetryDelay caps the delay before a single retry, including one requested with Retry-After.
+	maxRetryDelay = 30 * time.Second
+)
+
+// retryTransport resends requests that failed with a transient error, i.e. a 429 response, a 5xx
+// response, a connection error or an attempt running into attemptTimeout, with exponential backoff and
+// jitter, so that rate limits and short outages of the API do not abort applies. The delay requested by
+// the Retry-After header of the response takes precedence. Requests are only resent when that is safe:
+// 429 responses are never processed, and other failures are only retried for idempotent methods and
+// for POST requests with an idempotency key, which the API processes at most once. Conditional
+// requests are not resent after such failures, as they fail their precondition once processed.
+type retryTransport struct {
+	next http.RoundTripper
+	// attemptTimeout is how long a single attempt may take, or zero for no limit
+	attemptTimeout time.Duration
+}
+
+// RoundTrip sends the request, and resends it while it fails with a transient error. It stops as
+// soon as the context of the request is done, e.g. when the operation was cancelled.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTripAttempt(req, attempt)
+
+		// Failures are not retried once the operation was cancelled or ran out of time
+		if req.Context().Err() != nil || attempt >= maxRetries {
+			return resp, err
+		}
+
+		var delay time.Duration
+		switch {
+		case err != nil:
+			if !isIdempotent(req) {
+				return nil, err
+			}
+			delay = retryDelay(attempt, nil)
+			apiMetrics.recordRetry(req, nil)
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, err, delay, attempt+1, maxRetries)
+		case isRetryable(req, resp):
+			delay = retryDelay(attempt, resp.Header)
+			// Return the response when the request could not be resent before the deadline of the operation
+			if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(delay).After(deadline) {
+				return resp, nil
+			}
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.StatusCode, delay, attempt+1, maxRetries)
+			apiMetrics.recordRetry(req, resp)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attemptTimeoutContextKey is the context key marking operations whose attempts are not limited.
+type attemptTimeoutContextKey struct{}
+
+// withoutAttemptTimeout returns a context whose requests are not limited to the request timeout of the
+// provider, for operations that set their own deadline, e.g. from a timeouts block.
+func withoutAttemptTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attemptTimeoutContextKey{}, true)
+}
+
+// roundTripAttempt sends a single attempt of the request, limited to attemptTimeout unless the context
+// of the request lifts it. Retries send a copy of the request, with the same headers, including the
+// idempotency key, and a new body.
+func (t *retryTransport) roundTripAttempt(req *http.Request, attempt int) (*http.Response, error) {
+	timeout := t.attemptTimeout
+	if unlimited, _ := req.Context().Value(attemptTimeoutContextKey{}).(bool); unlimited {
+		timeout = 0
+	}
+
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	attemptReq := req
+	if attempt > 0 || timeout > 0 {
+		// A RoundTripper must not modify the request it was given
+		attemptReq = req.Clone(ctx)
+	}
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	resp, err := t.next.RoundTrip(attemptReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The attempt is over once its response body is closed
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody is a response body that releases the context of its attempt when closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context of the attempt.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isIdempotent reports whether the request can be resent without side effects, because its method is
+// idempotent or it carries an idempotency key. Requests whose body was consumed cannot be resent at all,
+// and conditional requests would fail their precondition if the first attempt was processed.
+func isIdempotent(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if req.Header.Get("If-Match") != "" {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return req.Header.Get(idempotencyKeyHeader) != ""
+	}
+	return false
+}
+
+// isRetryable reports whether the request can be resent after the response.
+func isRetryable(req *http.Request, resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// The request was not processed, but its body may still have been consumed
+		return !(req.Body != nil && req.Body != http.NoBody && req.GetBody == nil)
+	case resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before resending a request for the given attempt, which counts
+// from zero: the delay requested by the Retry-After header, or an exponential backoff with full jitter.
+func retryDelay(attempt int, header http.Header) time.Duration {
+	if delay, ok := retryAfter(header); ok {
+		if delay > maxRetryDelay {
+			return maxRetryDelay
+		}
+		return delay
+	}
+
+	backoff := retryBaseDelay << attempt
+	if backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+	// Wait between half and all of the backoff, so that concurrent requests spread out
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// retryAfter returns the delay requested by the Retry-After header, which holds a number of seconds
+// or an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the delay, or until the context is done.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
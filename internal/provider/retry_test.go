@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedRequest is a request received by a test server.
+type recordedRequest struct {
+	body           string
+	idempotencyKey string
+}
+
+// newRetryTestServer returns a server that answers the requests it receives with the given handlers
+// in turn, and the requests it received.
+func newRetryTestServer(t *testing.T, handlers ...http.HandlerFunc) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var received []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		attempt := len(received)
+		received = append(received, recordedRequest{body: string(body), idempotencyKey: r.Header.Get(idempotencyKeyHeader)})
+		mu.Unlock()
+
+		if attempt < len(handlers) {
+			handlers[attempt](w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), received...)
+	}
+}
+
+// respondWith answers with the status code, asking to retry right away.
+func respondWith(code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(code)
+	}
+}
+
+// hang answers after the client gave up on the request.
+func hang(w http.ResponseWriter, r *http.Request) {
+	select {
+	case <-r.Context().Done():
+	case <-time.After(5 * time.Second):
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		idempotencyKey string
+		ifMatch        string
+		handlers       []http.HandlerFunc
+		wantStatus     int
+		wantErr        bool
+		wantAttempts   int
+	}{
+		{
+			name:         "success is not retried",
+			method:       http.MethodGet,
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		{
+			name:         "rate limited POST without key is retried",
+			method:       http.MethodPost,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusTooManyRequests)},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "server error on GET is retried",
+			method:       http.MethodGet,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusBadGateway), respondWith(http.StatusServiceUnavailable)},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "server error on POST without key is not retried",
+			method:       http.MethodPost,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusServiceUnavailable)},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 1,
+		},
+		{
+			name:           "server error on POST with key is retried",
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			handlers:       []http.HandlerFunc{respondWith(http.StatusInternalServerError)},
+			wantStatus:     http.StatusOK,
+			wantAttempts:   2,
+		},
+		{
+			name:         "not implemented is not retried",
+			method:       http.MethodGet,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusNotImplemented)},
+			wantStatus:   http.StatusNotImplemented,
+			wantAttempts: 1,
+		},
+		{
+			name:         "client error is not retried",
+			method:       http.MethodPut,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusBadRequest)},
+			wantStatus:   http.StatusBadRequest,
+			wantAttempts: 1,
+		},
+		{
+			name:           "timed out POST with key is retried",
+			method:         http.MethodPost,
+			idempotencyKey: "key",
+			handlers:       []http.HandlerFunc{hang},
+			wantStatus:     http.StatusOK,
+			wantAttempts:   2,
+		},
+		{
+			name:         "timed out POST without key is not retried",
+			method:       http.MethodPost,
+			handlers:     []http.HandlerFunc{hang},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "timed out conditional PUT is not retried",
+			method:       http.MethodPut,
+			ifMatch:      `"v1"`,
+			handlers:     []http.HandlerFunc{hang},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "server error on conditional PUT is not retried",
+			method:       http.MethodPut,
+			ifMatch:      `"v1"`,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusBadGateway)},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:         "rate limited conditional PUT is retried",
+			method:       http.MethodPut,
+			ifMatch:      `"v1"`,
+			handlers:     []http.HandlerFunc{respondWith(http.StatusTooManyRequests)},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:   "retries are bounded",
+			method: http.MethodGet,
+			handlers: []http.HandlerFunc{
+				respondWith(http.StatusBadGateway), respondWith(http.StatusBadGateway), respondWith(http.StatusBadGateway),
+				respondWith(http.StatusBadGateway), respondWith(http.StatusBadGateway), respondWith(http.StatusBadGateway),
+			},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: maxRetries + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, received := newRetryTestServer(t, tt.handlers...)
+			client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, attemptTimeout: 200 * time.Millisecond}}
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(`{"name":"route"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.idempotencyKey != "" {
+				req.Header.Set(idempotencyKeyHeader, tt.idempotencyKey)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			resp, err := client.Do(req)
+			if tt.wantErr {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatalf("expected an error, got status %d", resp.StatusCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+				}
+			}
+
+			// Give the server time to record requests the client gave up on
+			time.Sleep(50 * time.Millisecond)
+			requests := received()
+			if len(requests) != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, len(requests))
+			}
+			for i, request := range requests {
+				if request.body != `{"name":"route"}` {
+					t.Errorf("attempt %d: expected the request body to be resent, got %q", i+1, request.body)
+				}
+				if request.idempotencyKey != tt.idempotencyKey {
+					t.Errorf("attempt %d: expected idempotency key %q, got %q", i+1, tt.idempotencyKey, request.idempotencyKey)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportStopsWhenCancelled(t *testing.T) {
+	server, received := newRetryTestServer(t, hang, hang, hang)
+	client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, attemptTimeout: time.Second}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(idempotencyKeyHeader, "key")
+
+	_, err = client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline of the operation to be exceeded, got: %v", err)
+	}
+	if attempts := len(received()); attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetryTransportWithoutAttemptTimeout(t *testing.T) {
+	slow := func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}
+	server, received := newRetryTestServer(t, slow)
+	client := &http.Client{Transport: &retryTransport{next: http.DefaultTransport, attemptTimeout: 100 * time.Millisecond}}
+
+	// The deadline of the operation, not the request timeout, limits its requests
+	ctx, cancel := withOperationTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("If-Match", `"v1"`)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected the slow attempt to complete, got: %s", err)
+	}
+	resp.Body.Close()
+	if attempts := len(received()); attempts != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := retryBaseDelay << attempt
+		if backoff > maxRetryDelay {
+			backoff = maxRetryDelay
+		}
+		if delay := retryDelay(attempt, nil); delay < backoff/2 || delay > backoff {
+			t.Errorf("attempt %d: expected a delay between %s and %s, got %s", attempt, backoff/2, backoff, delay)
+		}
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	if delay := retryDelay(0, header); delay != 3*time.Second {
+		t.Errorf("expected the Retry-After delay of 3s, got %s", delay)
+	}
+
+	header.Set("Retry-After", "3600")
+	if delay := retryDelay(0, header); delay != maxRetryDelay {
+		t.Errorf("expected the Retry-After delay to be capped at %s, got %s", maxRetryDelay, delay)
+	}
+}
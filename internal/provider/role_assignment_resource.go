@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleAssignmentResource{}
+var _ resource.ResourceWithImportState = &RoleAssignmentResource{}
+var _ resource.ResourceWithValidateConfig = &RoleAssignmentResource{}
+
+// roles lists the roles that can be assigned in the Pomerium Zero console.
+var roles = []string{"admin", "editor", "viewer"}
+
+// NewRoleAssignmentResource creates a new RoleAssignmentResource.
+func NewRoleAssignmentResource() resource.Resource {
+	return &RoleAssignmentResource{}
+}
+
+// RoleAssignmentResource defines the resource implementation.
+type RoleAssignmentResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// RoleAssignmentResourceModel describes the resource data model.
+type RoleAssignmentResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Role             types.String `tfsdk:"role"`
+	UserID           types.String `tfsdk:"user_id"`
+	ServiceAccountID types.String `tfsdk:"service_account_id"`
+	NamespaceID      types.String `tfsdk:"namespace_id"`
+}
+
+// Metadata sets the resource type name for the RoleAssignmentResource.
+func (r *RoleAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_assignment"
+}
+
+// Schema defines the structure and attributes of the RoleAssignmentResource.
+func (r *RoleAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns a role in the Pomerium Zero console to a member or a service account, either for the whole organization " +
+			"or for a single namespace. Exactly one of `user_id` or `service_account_id` must be configured.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the role assignment.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role to assign. One of `admin`, `editor` or `viewer`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf(roles...),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the member the role is assigned to.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the service account the role is assigned to.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace the role applies to. When not set, the role applies to the whole organization.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that the role is assigned to exactly one principal.
+func (r *RoleAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The principal may not be known until apply
+	if data.UserID.IsUnknown() || data.ServiceAccountID.IsUnknown() {
+		return
+	}
+
+	if data.UserID.IsNull() == data.ServiceAccountID.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Role Assignment Configuration",
+			"Exactly one of user_id or service_account_id must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the RoleAssignmentResource.
+func (r *RoleAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create assigns the role.
+func (r *RoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/role-assignments", apiBaseURL, r.organizationID)
+
+	var assignment RoleAssignment
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, createRoleAssignmentRequest(plan), http.StatusCreated, &assignment); err != nil {
+		resp.Diagnostics.AddError("Error creating role assignment", err.Error())
+		return
+	}
+
+	updateRoleAssignmentResourceModel(&plan, &assignment)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read retrieves the role assignment.
+func (r *RoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignment, err := r.getRoleAssignment(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading role assignment", err.Error())
+		return
+	}
+
+	updateRoleAssignmentResourceModel(&state, assignment)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the assigned role. Changing the principal or the scope replaces the assignment.
+func (r *RoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/role-assignments/%s", apiBaseURL, r.organizationID, plan.ID.ValueString())
+
+	var assignment RoleAssignment
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, createRoleAssignmentRequest(plan), http.StatusOK, &assignment); err != nil {
+		resp.Diagnostics.AddError("Error updating role assignment", err.Error())
+		return
+	}
+
+	updateRoleAssignmentResourceModel(&plan, &assignment)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete revokes the role.
+func (r *RoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/role-assignments/%s", apiBaseURL, r.organizationID, state.ID.ValueString())
+	if err := doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil); err != nil {
+		resp.Diagnostics.AddError("Error deleting role assignment", err.Error())
+		return
+	}
+}
+
+// ImportState imports a role assignment by its ID.
+func (r *RoleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	assignment, err := r.getRoleAssignment(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing role assignment", fmt.Sprintf("Unable to read role assignment %s, error: %s", req.ID, err))
+		return
+	}
+
+	var state RoleAssignmentResourceModel
+	updateRoleAssignmentResourceModel(&state, assignment)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// getRoleAssignment fetches a role assignment by its ID.
+func (r *RoleAssignmentResource) getRoleAssignment(ctx context.Context, assignmentID string) (*RoleAssignment, error) {
+	url := fmt.Sprintf("%s/organizations/%s/role-assignments/%s", apiBaseURL, r.organizationID, assignmentID)
+
+	var assignment RoleAssignment
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &assignment); err != nil {
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+// createRoleAssignmentRequest creates the API request body from the RoleAssignmentResourceModel.
+func createRoleAssignmentRequest(model RoleAssignmentResourceModel) RoleAssignment {
+	return RoleAssignment{
+		Role:             model.Role.ValueString(),
+		UserID:           model.UserID.ValueString(),
+		ServiceAccountID: model.ServiceAccountID.ValueString(),
+		NamespaceID:      model.NamespaceID.ValueString(),
+	}
+}
+
+// updateRoleAssignmentResourceModel updates the model with the role assignment returned by the API.
+func updateRoleAssignmentResourceModel(model *RoleAssignmentResourceModel, assignment *RoleAssignment) {
+	model.ID = types.StringValue(assignment.ID)
+	model.Role = types.StringValue(assignment.Role)
+	model.UserID = stringValueOrNull(assignment.UserID)
+	model.ServiceAccountID = stringValueOrNull(assignment.ServiceAccountID)
+	model.NamespaceID = stringValueOrNull(assignment.NamespaceID)
+}
@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RouteDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RouteDataSource{}
+
+// NewRouteDataSource creates a new RouteDataSource.
+func NewRouteDataSource() datasource.DataSource {
+	return &RouteDataSource{}
+}
+
+// RouteDataSource defines the data source implementation.
+type RouteDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// RouteDataSourceModel describes the data source data model.
+type RouteDataSourceModel struct {
+	ID                                        types.String `tfsdk:"id"`
+	Name                                      types.String `tfsdk:"name"`
+	NamespaceID                               types.String `tfsdk:"namespace_id"`
+	From                                      types.String `tfsdk:"from"`
+	To                                        types.List   `tfsdk:"to"`
+	AllowSpdy                                 types.Bool   `tfsdk:"allow_spdy"`
+	AllowWebsockets                           types.Bool   `tfsdk:"allow_websockets"`
+	EnableGoogleCloudServerlessAuthentication types.Bool   `tfsdk:"enable_google_cloud_serverless_authentication"`
+	PassIdentityHeaders                       types.Bool   `tfsdk:"pass_identity_headers"`
+	PreserveHostHeader                        types.Bool   `tfsdk:"preserve_host_header"`
+	ShowErrorDetails                          types.Bool   `tfsdk:"show_error_details"`
+	TLSSkipVerify                             types.Bool   `tfsdk:"tls_skip_verify"`
+	TLSUpstreamAllowRenegotiation             types.Bool   `tfsdk:"tls_upstream_allow_renegotiation"`
+	TLSDownstreamServerName                   types.String `tfsdk:"tls_downstream_server_name"`
+	TLSDownstreamClientCA                     types.String `tfsdk:"tls_downstream_client_ca"`
+	PolicyIDs                                 types.List   `tfsdk:"policy_ids"`
+	Prefix                                    types.String `tfsdk:"prefix"`
+	PrefixRewrite                             types.String `tfsdk:"prefix_rewrite"`
+	KubernetesServiceAccountToken             types.String `tfsdk:"kubernetes_service_account_token"`
+}
+
+// Metadata sets the data source type name for the RouteDataSource.
+func (d *RouteDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_route"
+}
+
+// Schema defines the structure and attributes of the RouteDataSource.
+func (d *RouteDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a single route by name or by its `from` URL, e.g. to reference a route that is not managed in this workspace. " +
+			"Exactly one of `name` or `from` must be configured. The lookup fails when no route or more than one route matches.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the route.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the route to look up.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace to look up the route in, including its child namespaces. " +
+					"When not set, the route is looked up in the whole organization.",
+				Optional: true,
+				Computed: true,
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "The source URL of the route to look up.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"to": schema.ListAttribute{
+				MarkdownDescription: "The destination URLs of the route.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"allow_spdy": schema.BoolAttribute{
+				MarkdownDescription: "Whether the SPDY protocol is allowed for the route.",
+				Computed:            true,
+			},
+			"allow_websockets": schema.BoolAttribute{
+				MarkdownDescription: "Whether WebSocket connections are allowed for the route.",
+				Computed:            true,
+			},
+			"enable_google_cloud_serverless_authentication": schema.BoolAttribute{
+				MarkdownDescription: "Whether Google Cloud Serverless Authentication is enabled for the route.",
+				Computed:            true,
+			},
+			"pass_identity_headers": schema.BoolAttribute{
+				MarkdownDescription: "Whether identity headers are passed to the upstream service.",
+				Computed:            true,
+			},
+			"preserve_host_header": schema.BoolAttribute{
+				MarkdownDescription: "Whether the original host header is preserved when proxying requests.",
+				Computed:            true,
+			},
+			"show_error_details": schema.BoolAttribute{
+				MarkdownDescription: "Whether detailed error messages are shown when errors occur.",
+				Computed:            true,
+			},
+			"tls_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether TLS verification is skipped for upstream connections.",
+				Computed:            true,
+			},
+			"tls_upstream_allow_renegotiation": schema.BoolAttribute{
+				MarkdownDescription: "Whether TLS renegotiation is allowed for upstream connections.",
+				Computed:            true,
+			},
+			"tls_downstream_server_name": schema.StringAttribute{
+				MarkdownDescription: "The server name that overrides the hostname of the `from` URL for downstream TLS.",
+				Computed:            true,
+			},
+			"tls_downstream_client_ca": schema.StringAttribute{
+				MarkdownDescription: "The PEM encoded CA certificates that issue the client certificates required by the route.",
+				Computed:            true,
+			},
+			"policy_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the policies associated with the route.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "The URL prefix matched by the route.",
+				Computed:            true,
+			},
+			"prefix_rewrite": schema.StringAttribute{
+				MarkdownDescription: "The prefix the matched URL prefix is rewritten to before forwarding the request.",
+				Computed:            true,
+			},
+			"kubernetes_service_account_token": schema.StringAttribute{
+				MarkdownDescription: "The Kubernetes service account token used for authentication.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that the route is looked up by exactly one of name or from.
+func (d *RouteDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data RouteDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Name.IsUnknown() || data.From.IsUnknown() {
+		return
+	}
+
+	if data.Name.IsNull() == data.From.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Route Lookup",
+			"Exactly one of name or from must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the RouteDataSource.
+func (d *RouteDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read looks up the route and maps all of its attributes.
+func (d *RouteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RouteDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routes, err := d.getRoutes(ctx, data.NamespaceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error fetching routes", err.Error())
+		return
+	}
+
+	attribute, value := "name", data.Name.ValueString()
+	if data.Name.IsNull() {
+		attribute, value = "from", data.From.ValueString()
+	}
+
+	var matches []map[string]interface{}
+	for _, route := range routes {
+		if route[attribute] == value {
+			matches = append(matches, route)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("Route Not Found", fmt.Sprintf("No route found with %s: %s", attribute, value))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple Routes Found",
+			fmt.Sprintf("Found %d routes with %s: %s. Set namespace_id to narrow down the lookup.", len(matches), attribute, value),
+		)
+		return
+	}
+
+	route, diags := mapRouteResponseToModel(ctx, matches[0])
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data = RouteDataSourceModel{
+		ID:              route.ID,
+		Name:            route.Name,
+		NamespaceID:     route.NamespaceID,
+		From:            route.From,
+		To:              listOrNull(route.To),
+		AllowSpdy:       route.AllowSpdy,
+		AllowWebsockets: route.AllowWebsockets,
+		EnableGoogleCloudServerlessAuthentication: route.EnableGoogleCloudServerlessAuthentication,
+		PassIdentityHeaders:                       route.PassIdentityHeaders,
+		PreserveHostHeader:                        route.PreserveHostHeader,
+		ShowErrorDetails:                          route.ShowErrorDetails,
+		TLSSkipVerify:                             route.TLSSkipVerify,
+		TLSUpstreamAllowRenegotiation:             route.TLSUpstreamAllowRenegotiation,
+		TLSDownstreamServerName:                   route.TLSDownstreamServerName,
+		TLSDownstreamClientCA:                     route.TLSDownstreamClientCA,
+		PolicyIDs:                                 listOrNull(route.PolicyIDs),
+		Prefix:                                    route.Prefix,
+		PrefixRewrite:                             route.PrefixRewrite,
+		KubernetesServiceAccountToken:             route.KubernetesServiceAccountToken,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// getRoutes fetches all routes, optionally limited to a namespace and its descendants.
+func (d *RouteDataSource) getRoutes(ctx context.Context, namespaceID string) ([]map[string]interface{}, error) {
+	routesURL := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, d.organizationID)
+	if namespaceID != "" {
+		routesURL += "?namespaceId=" + url.QueryEscape(namespaceID) + "&includeDescendants=true"
+	}
+
+	var routes []map[string]interface{}
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", routesURL, nil, http.StatusOK, &routes); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// listOrNull returns a typed null list of strings when the list was not set by mapRouteResponseToModel,
+// since the zero value lacks the element type needed to store it in the state.
+func listOrNull(list types.List) types.List {
+	if list.IsNull() {
+		return types.ListNull(types.StringType)
+	}
+	return list
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// routeDefaultsModel describes the data model of the route_defaults block of the provider.
+type routeDefaultsModel struct {
+	AllowWebsockets     types.Bool `tfsdk:"allow_websockets"`
+	PassIdentityHeaders types.Bool `tfsdk:"pass_identity_headers"`
+	PreserveHostHeader  types.Bool `tfsdk:"preserve_host_header"`
+}
+
+// routeDefaultsBlock returns the schema of the route_defaults block of the provider.
+func routeDefaultsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Defaults for the attributes of all `pomeriumzero_route` resources. " +
+			"Attributes set on a route take precedence over these defaults.",
+		Attributes: map[string]schema.Attribute{
+			"allow_websockets": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Default for `allow_websockets` of routes.",
+			},
+			"pass_identity_headers": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Default for `pass_identity_headers` of routes.",
+			},
+			"preserve_host_header": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Default for `preserve_host_header` of routes.",
+			},
+		},
+	}
+}
+
+// apply sets the route attributes that are not set in the configuration to their default in the plan.
+func (d *routeDefaultsModel) apply(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d == nil {
+		return diags
+	}
+
+	defaults := []struct {
+		name  string
+		value types.Bool
+	}{
+		{"allow_websockets", d.AllowWebsockets},
+		{"pass_identity_headers", d.PassIdentityHeaders},
+		{"preserve_host_header", d.PreserveHostHeader},
+	}
+
+	for _, attribute := range defaults {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+
+		var configured types.Bool
+		diags.Append(config.GetAttribute(ctx, path.Root(attribute.name), &configured)...)
+		if diags.HasError() {
+			return diags
+		}
+		if !configured.IsNull() {
+			continue
+		}
+
+		diags.Append(plan.SetAttribute(ctx, path.Root(attribute.name), attribute.value)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	// routeFromYAMLStringAttributes lists the string attributes of pomeriumzero_route converted from an open-source Pomerium route.
+	routeFromYAMLStringAttributes = []string{
+		"name", "from", "tls_downstream_server_name", "prefix", "prefix_rewrite", "kubernetes_service_account_token",
+	}
+
+	// routeFromYAMLBoolAttributes lists the boolean attributes of pomeriumzero_route converted from an open-source Pomerium route.
+	routeFromYAMLBoolAttributes = []string{
+		"allow_spdy", "allow_websockets", "enable_google_cloud_serverless_authentication", "pass_identity_headers",
+		"preserve_host_header", "show_error_details", "tls_skip_verify", "tls_upstream_allow_renegotiation",
+	}
+)
+
+// routeFromYAMLAttributeTypes returns the attribute types of the object returned by RouteFromYAMLFunction.
+func routeFromYAMLAttributeTypes() map[string]attr.Type {
+	attributeTypes := map[string]attr.Type{
+		"to": types.ListType{ElemType: types.StringType},
+	}
+	for _, name := range routeFromYAMLStringAttributes {
+		attributeTypes[name] = types.StringType
+	}
+	for _, name := range routeFromYAMLBoolAttributes {
+		attributeTypes[name] = types.BoolType
+	}
+	return attributeTypes
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RouteFromYAMLFunction{}
+
+// NewRouteFromYAMLFunction creates a new RouteFromYAMLFunction.
+func NewRouteFromYAMLFunction() function.Function {
+	return &RouteFromYAMLFunction{}
+}
+
+// RouteFromYAMLFunction converts an open-source Pomerium route to the attributes of pomeriumzero_route.
+type RouteFromYAMLFunction struct{}
+
+// Metadata sets the name of the RouteFromYAMLFunction.
+func (f *RouteFromYAMLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "route_from_yaml"
+}
+
+// Definition defines the parameters and return type of the RouteFromYAMLFunction.
+func (f *RouteFromYAMLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts an open-source Pomerium route to pomeriumzero_route attributes",
+		MarkdownDescription: "Converts a route of an open-source Pomerium configuration file, written in YAML, to an object " +
+			"holding the attributes of `pomeriumzero_route`, to ease migrating self-hosted routes to Pomerium Zero. " +
+			"Fields the route does not set are `null`, and `name` defaults to the `from` URL followed by the `prefix` or `path`. " +
+			"`namespace_id` and `policy_ids` are not part of the object: inline policies such as `policy` or `allowed_users` " +
+			"raise an error and must be migrated to `pomeriumzero_policy` resources, as must any other unsupported field.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "yaml",
+				MarkdownDescription: "The YAML of a single route, as a mapping or a sequence holding one mapping.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: routeFromYAMLAttributeTypes(),
+		},
+	}
+}
+
+// Run converts the route.
+func (f *RouteFromYAMLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	decoded, err := decodeYAML([]byte(document))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid YAML: %s", err))
+		return
+	}
+	if items, ok := decoded.([]interface{}); ok && len(items) == 1 {
+		decoded = items[0]
+	}
+	fields, ok := decoded.(map[string]interface{})
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "The YAML must hold a single route mapping.")
+		return
+	}
+
+	route, err := convertOSSRoute(fields)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid route: %s.", err))
+		return
+	}
+
+	result, diags := types.ObjectValue(routeFromYAMLAttributeTypes(), route)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// convertOSSRoute converts the fields of an open-source Pomerium route to the attribute values of the object
+// returned by RouteFromYAMLFunction.
+func convertOSSRoute(fields map[string]interface{}) (map[string]attr.Value, error) {
+	route := make(map[string]attr.Value, len(fields))
+	for _, name := range routeFromYAMLStringAttributes {
+		route[name] = types.StringNull()
+	}
+	for _, name := range routeFromYAMLBoolAttributes {
+		route[name] = types.BoolNull()
+	}
+	route["to"] = types.ListNull(types.StringType)
+
+	var unsupported []string
+	for _, key := range sortedKeys(fields) {
+		value := fields[key]
+		switch {
+		case key == "to":
+			to, err := ossRouteTo(value)
+			if err != nil {
+				return nil, err
+			}
+			route["to"] = to
+		case containsString(routeFromYAMLStringAttributes, key):
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("field %s must be a string, got: %v", key, value)
+			}
+			route[key] = types.StringValue(s)
+		case containsString(routeFromYAMLBoolAttributes, key):
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("field %s must be a boolean, got: %v", key, value)
+			}
+			route[key] = types.BoolValue(b)
+		case key == "path":
+			// Prefix and path only differ in matching, so both are only used for the default name
+		case key == "policy" || strings.HasPrefix(key, "allow_any_authenticated_user") || strings.HasPrefix(key, "allowed_") || key == "allow_public_unauthenticated_access":
+			return nil, fmt.Errorf("field %s is an inline policy, which is not supported; "+
+				"create the policy with a pomeriumzero_policy resource and reference it with policy_ids instead", key)
+		default:
+			unsupported = append(unsupported, key)
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("unsupported fields: %s", strings.Join(unsupported, ", "))
+	}
+
+	from, _ := fields["from"].(string)
+	if from == "" {
+		return nil, fmt.Errorf("the route must have a from URL")
+	}
+	if route["to"].IsNull() {
+		return nil, fmt.Errorf("the route must have a to URL")
+	}
+
+	if name, _ := fields["name"].(string); name == "" {
+		name = from
+		for _, key := range []string{"prefix", "path"} {
+			if suffix, ok := fields[key].(string); ok {
+				name += suffix
+			}
+		}
+		route["name"] = types.StringValue(name)
+	}
+
+	return route, nil
+}
+
+// ossRouteTo converts the to field of an open-source Pomerium route, which is a URL or a list of URLs.
+func ossRouteTo(value interface{}) (attr.Value, error) {
+	var items []interface{}
+	switch value := value.(type) {
+	case string:
+		items = []interface{}{value}
+	case []interface{}:
+		items = value
+	default:
+		return nil, fmt.Errorf("field to must be a URL or a list of URLs, got: %v", value)
+	}
+
+	to := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("field to must only hold URLs, got: %v", item)
+		}
+		to = append(to, types.StringValue(s))
+	}
+	return types.ListValueMust(types.StringType, to), nil
+}
@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RoutePoliciesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &RoutePoliciesDataSource{}
+
+// NewRoutePoliciesDataSource creates a new RoutePoliciesDataSource.
+func NewRoutePoliciesDataSource() datasource.DataSource {
+	return &RoutePoliciesDataSource{}
+}
+
+// RoutePoliciesDataSource defines the data source implementation.
+type RoutePoliciesDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// RoutePoliciesDataSourceModel describes the data source data model.
+type RoutePoliciesDataSourceModel struct {
+	RouteID     types.String                  `tfsdk:"route_id"`
+	Name        types.String                  `tfsdk:"name"`
+	NamespaceID types.String                  `tfsdk:"namespace_id"`
+	PolicyIDs   []types.String                `tfsdk:"policy_ids"`
+	Policies    []RoutePolicySummaryDataModel `tfsdk:"policies"`
+}
+
+// RoutePolicySummaryDataModel describes a single policy that applies to the route.
+type RoutePolicySummaryDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	NamespaceID types.String `tfsdk:"namespace_id"`
+	Enforced    types.Bool   `tfsdk:"enforced"`
+	Attached    types.Bool   `tfsdk:"attached"`
+}
+
+// Metadata sets the data source type name for the RoutePoliciesDataSource.
+func (d *RoutePoliciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_route_policies"
+}
+
+// Schema defines the structure and attributes of the RoutePoliciesDataSource.
+func (d *RoutePoliciesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the policies that apply to a route: those attached to it, and those enforced in its namespace or a parent namespace. " +
+			"Useful in `check` blocks, e.g. to assert that critical routes always carry a baseline policy.",
+		Attributes: map[string]schema.Attribute{
+			"route_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the route. Exactly one of `route_id` and `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringIsID(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the route. Exactly one of `route_id` and `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace to look up the route by name in, including its child namespaces. " +
+					"Set to the namespace of the route.",
+				Optional: true,
+				Computed: true,
+			},
+			"policy_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the policies that apply to the route, sorted.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"policies": schema.ListNestedAttribute{
+				MarkdownDescription: "The policies that apply to the route, sorted by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the policy.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the policy.",
+							Computed:            true,
+						},
+						"namespace_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the namespace the policy belongs to.",
+							Computed:            true,
+						},
+						"enforced": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is enforced on all routes in its namespace.",
+							Computed:            true,
+						},
+						"attached": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is attached to the route, rather than only applying because it is enforced.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that the route is looked up by exactly one of route_id or name.
+func (d *RoutePoliciesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data RoutePoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RouteID.IsUnknown() || data.Name.IsUnknown() {
+		return
+	}
+
+	if data.RouteID.IsNull() == data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Invalid Route Lookup",
+			"Exactly one of route_id or name must be configured.",
+		)
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the RoutePoliciesDataSource.
+func (d *RoutePoliciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read looks up the route and the policies that apply to it.
+func (d *RoutePoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RoutePoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routes := &RouteDataSource{client: d.client, token: d.token, organizationID: d.organizationID}
+	allRoutes, err := routes.getRoutes(ctx, data.NamespaceID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error fetching routes", "Unable to list routes", err)...)
+		return
+	}
+
+	attribute, value := "id", data.RouteID.ValueString()
+	if data.RouteID.IsNull() {
+		attribute, value = "name", data.Name.ValueString()
+	}
+
+	var matches []map[string]interface{}
+	for _, route := range allRoutes {
+		if route[attribute] == value {
+			matches = append(matches, route)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("Route Not Found", fmt.Sprintf("No route found with %s: %s", attribute, value))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddError(
+			"Multiple Routes Found",
+			fmt.Sprintf("Found %d routes with %s: %s. Set namespace_id to narrow down the lookup.", len(matches), attribute, value),
+		)
+		return
+	}
+
+	route, diags := mapRouteResponseToModel(ctx, matches[0])
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var attachedIDs []string
+	if !route.PolicyIDs.IsNull() {
+		resp.Diagnostics.Append(route.PolicyIDs.ElementsAs(ctx, &attachedIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var policies []Policy
+	url := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, d.organizationID)
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &policies); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error fetching policies", "Unable to list policies", err)...)
+		return
+	}
+
+	var namespaces []Namespace
+	url = fmt.Sprintf("%s/organizations/%s/namespaces", apiBaseURL, d.organizationID)
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &namespaces); err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Error fetching namespaces", "Unable to list namespaces", err)...)
+		return
+	}
+
+	namespacesByID := make(map[string]Namespace, len(namespaces))
+	for _, namespace := range namespaces {
+		namespacesByID[namespace.ID] = namespace
+	}
+
+	// Policies enforced in the namespace of the route or one of its parents apply, guarding against cycles
+	enforcedIn := make(map[string]bool)
+	for id := route.NamespaceID.ValueString(); id != "" && !enforcedIn[id]; id = namespacesByID[id].ParentID {
+		enforcedIn[id] = true
+	}
+
+	attached := make(map[string]bool, len(attachedIDs))
+	for _, id := range attachedIDs {
+		attached[id] = true
+	}
+
+	data.RouteID = route.ID
+	data.Name = route.Name
+	data.NamespaceID = route.NamespaceID
+	data.PolicyIDs = []types.String{}
+	data.Policies = []RoutePolicySummaryDataModel{}
+
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	for _, policy := range policies {
+		if !attached[policy.ID] && !(policy.Enforced && enforcedIn[policy.NamespaceID]) {
+			continue
+		}
+		data.PolicyIDs = append(data.PolicyIDs, types.StringValue(policy.ID))
+		data.Policies = append(data.Policies, RoutePolicySummaryDataModel{
+			ID:          types.StringValue(policy.ID),
+			Name:        types.StringValue(policy.Name),
+			NamespaceID: types.StringValue(policy.NamespaceID),
+			Enforced:    types.BoolValue(policy.Enforced),
+			Attached:    types.BoolValue(attached[policy.ID]),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// routePublicURL returns the URL at which a route is reachable from outside the cluster. A from URL
+// whose host is a single label, such as https://app, is a subdomain of the cluster, so the FQDN of the
+// cluster is appended to it. The prefix of the route, if any, is appended to the path.
+func routePublicURL(from, prefix, clusterFQDN string) (string, error) {
+	target, err := url.Parse(from)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse from URL %q: %w", from, err)
+	}
+
+	host := strings.ToLower(target.Hostname())
+	if !strings.Contains(host, ".") {
+		if clusterFQDN == "" {
+			return "", fmt.Errorf("the from URL %q is a subdomain of the cluster, but the cluster has no FQDN", from)
+		}
+		host += "." + clusterFQDN
+	}
+	if port := target.Port(); port != "" {
+		host += ":" + port
+	}
+	target.Host = host
+
+	if prefix != "" {
+		target.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(prefix, "/")
+	}
+
+	return target.String(), nil
+}
+
+// setPublicURL computes the public_url of a route from its from URL and prefix. The cluster of the
+// namespace of the route is only looked up when the from URL is a subdomain of the cluster.
+func (r *RouteResource) setPublicURL(ctx context.Context, model *RouteResourceModel) {
+	from := model.From.ValueString()
+
+	var fqdn string
+	if target, err := url.Parse(from); err == nil && !strings.Contains(target.Hostname(), ".") {
+		fqdn, err = r.clusterFQDN(ctx, model.NamespaceID.ValueString())
+		if err != nil {
+			log.Printf("[DEBUG] Unable to look up the cluster of route %s: %s", model.ID.ValueString(), err)
+		}
+	}
+
+	publicURL, err := routePublicURL(from, model.Prefix.ValueString(), fqdn)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to compute the public URL of route %s: %s", model.ID.ValueString(), err)
+		model.PublicURL = types.StringNull()
+		return
+	}
+	model.PublicURL = types.StringValue(publicURL)
+}
+
+// clusterFQDN returns the FQDN of the cluster whose namespace is namespaceID.
+func (r *RouteResource) clusterFQDN(ctx context.Context, namespaceID string) (string, error) {
+	url := fmt.Sprintf("%s/organizations/%s/clusters", apiBaseURL, r.organizationID)
+	var clusters []Cluster
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &clusters); err != nil {
+		return "", fmt.Errorf("error listing clusters: %w", err)
+	}
+
+	for _, cluster := range clusters {
+		if cluster.NamespaceID == namespaceID {
+			return cluster.FQDN, nil
+		}
+	}
+	return "", fmt.Errorf("no cluster found for namespace %s", namespaceID)
+}
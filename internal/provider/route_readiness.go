@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	// defaultReadyTimeout is how long to probe a route when the wait_for_ready block sets no timeout.
+	defaultReadyTimeout = 5 * time.Minute
+	// defaultReadyInterval is the delay between two probes when the wait_for_ready block sets no interval.
+	defaultReadyInterval = 10 * time.Second
+	// readyProbeTimeout bounds a single probe.
+	readyProbeTimeout = 10 * time.Second
+)
+
+// waitForReadyModel describes the data model of the wait_for_ready block of routes.
+type waitForReadyModel struct {
+	ExpectedStatus types.Int64  `tfsdk:"expected_status"`
+	Timeout        types.String `tfsdk:"timeout"`
+	Interval       types.String `tfsdk:"interval"`
+}
+
+// waitForReadyAttrTypes are the attribute types of the wait_for_ready block, needed to build null
+// values for it, e.g. when importing a route.
+var waitForReadyAttrTypes = map[string]attr.Type{
+	"expected_status": types.Int64Type,
+	"timeout":         types.StringType,
+	"interval":        types.StringType,
+}
+
+// waitForReadyBlock returns the schema of the wait_for_ready block of routes.
+func waitForReadyBlock() resource_schema.Block {
+	return resource_schema.SingleNestedBlock{
+		MarkdownDescription: "Probes the `from` URL of the route after it is created or updated, until it responds. " +
+			"This catches DNS and certificate propagation issues in the Terraform run instead of in the browsers of users. " +
+			"Routes whose `from` URL does not use the `https` scheme are not probed.",
+		Attributes: map[string]resource_schema.Attribute{
+			"expected_status": resource_schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "The HTTP status code the route must respond with, e.g. `302` for the redirect to the login page. " +
+					"When not set, any response is accepted, which verifies that the hostname resolves and the certificate is valid.",
+			},
+			"timeout": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to probe the route before failing, as a duration such as `5m`. Defaults to `5m`.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+			"interval": resource_schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait between two probes, as a duration such as `10s`. Defaults to `10s`.",
+				Validators: []validator.String{
+					stringIsDuration(),
+				},
+			},
+		},
+	}
+}
+
+// waitForRouteReady probes the from URL of a route, as configured by its wait_for_ready block,
+// until it responds with the expected status or the timeout elapses. Nothing is probed when the
+// block is not set.
+func waitForRouteReady(ctx context.Context, block types.Object, from, prefix string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if block.IsNull() || block.IsUnknown() {
+		return diags
+	}
+
+	var model waitForReadyModel
+	diags.Append(block.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	timeout, err := durationOrDefault(model.Timeout, defaultReadyTimeout)
+	if err != nil {
+		diags.AddError("Invalid Readiness Timeout", "Unable to parse wait_for_ready.timeout: "+err.Error())
+		return diags
+	}
+	interval, err := durationOrDefault(model.Interval, defaultReadyInterval)
+	if err != nil {
+		diags.AddError("Invalid Readiness Interval", "Unable to parse wait_for_ready.interval: "+err.Error())
+		return diags
+	}
+
+	target, err := url.Parse(from)
+	if err != nil {
+		diags.AddError("Invalid Route URL", fmt.Sprintf("Unable to parse from URL %q: %s", from, err))
+		return diags
+	}
+	if target.Scheme != "https" {
+		log.Printf("[INFO] Not probing route %s, as only https routes can be probed", from)
+		return diags
+	}
+	if prefix != "" {
+		target.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(prefix, "/")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The route is probed like a browser would, without the credentials of the API
+	client := &http.Client{
+		Timeout: readyProbeTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	for {
+		status, err := probeRoute(ctx, client, target.String())
+		switch {
+		case err != nil:
+			log.Printf("[DEBUG] Route %s is not ready: %s", target, err)
+		case model.ExpectedStatus.IsNull() || int64(status) == model.ExpectedStatus.ValueInt64():
+			return diags
+		default:
+			err = fmt.Errorf("unexpected status code: %d", status)
+			log.Printf("[DEBUG] Route %s is not ready: %s", target, err)
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				diags.AddError(
+					"Route Not Ready",
+					fmt.Sprintf("The route %s did not become ready within %s. Last error: %s", target, timeout, err),
+				)
+				return diags
+			}
+			return errorDiagnostics("Route Not Ready", ctx.Err().Error(), ctx.Err())
+		}
+	}
+}
+
+// probeRoute sends a GET request to the route and returns the status code of its response.
+func probeRoute(ctx context.Context, client *http.Client, target string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// durationOrDefault parses an optional duration attribute, returning defaultValue when it is not set.
+func durationOrDefault(value types.String, defaultValue time.Duration) (time.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue, nil
+	}
+	return time.ParseDuration(value.ValueString())
+}
@@ -4,17 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,6 +27,7 @@ import (
 var (
 	_ resource.Resource                = &RouteResource{}
 	_ resource.ResourceWithImportState = &RouteResource{}
+	_ resource.ResourceWithModifyPlan  = &RouteResource{}
 )
 
 // NewRouteResource is a helper function to simplify the provider implementation.
@@ -34,8 +40,17 @@ type RouteResource struct {
 	client         *http.Client
 	token          string
 	organizationID string
+	routeDefaults  *routeDefaultsModel
 }
 
+var (
+	// routeFromSchemes lists the schemes accepted in the from URL of a route.
+	routeFromSchemes = []string{"https", "tcp+https", "udp+https"}
+
+	// routeToSchemes lists the schemes accepted in the to URLs of a route.
+	routeToSchemes = []string{"http", "https", "h2c", "tcp", "udp"}
+)
+
 // RouteResourceModel describes the resource data model.
 type RouteResourceModel struct {
 	ID                                        types.String `tfsdk:"id"`
@@ -52,10 +67,18 @@ type RouteResourceModel struct {
 	TLSSkipVerify                             types.Bool   `tfsdk:"tls_skip_verify"`
 	TLSUpstreamAllowRenegotiation             types.Bool   `tfsdk:"tls_upstream_allow_renegotiation"`
 	TLSDownstreamServerName                   types.String `tfsdk:"tls_downstream_server_name"`
+	TLSDownstreamClientCA                     types.String `tfsdk:"tls_downstream_client_ca"`
 	PolicyIDs                                 types.List   `tfsdk:"policy_ids"`
 	Prefix                                    types.String `tfsdk:"prefix"`
 	PrefixRewrite                             types.String `tfsdk:"prefix_rewrite"`
 	KubernetesServiceAccountToken             types.String `tfsdk:"kubernetes_service_account_token"`
+	KubernetesServiceAccountTokenVersion      types.String `tfsdk:"kubernetes_service_account_token_version"`
+	DeletionProtection                        types.Bool   `tfsdk:"deletion_protection"`
+	WaitForDeployment                         types.Bool   `tfsdk:"wait_for_deployment"`
+	WaitForReady                              types.Object `tfsdk:"wait_for_ready"`
+	AdoptExisting                             types.Bool   `tfsdk:"adopt_existing"`
+	Upsert                                    types.Bool   `tfsdk:"upsert"`
+	PublicURL                                 types.String `tfsdk:"public_url"`
 }
 
 // Metadata sets the resource type name for the RouteResource.
@@ -90,17 +113,29 @@ func (r *RouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"namespace_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The ID of the namespace where the route will be created.",
+				Validators: []validator.String{
+					stringIsID(),
+				},
 			},
 			// Source URL for the route, required field
 			"from": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The source URL for the route. This is the URL that Pomerium will listen on.",
+				Validators: []validator.String{
+					stringIsURL(routeFromSchemes...),
+				},
 			},
 			// Destination URLs for the route, required field
 			"to": schema.ListAttribute{
-				ElementType:         types.StringType,
-				Required:            true,
-				MarkdownDescription: "A list of destination URLs for the route. These are the backend servers that Pomerium will forward requests to.",
+				ElementType: types.StringType,
+				Required:    true,
+				MarkdownDescription: "A list of destination URLs for the route. These are the backend servers that Pomerium will forward requests to. " +
+					"All URLs must have the same scheme, one of `http`, `https`, `h2c`, `tcp` and `udp`.",
+				Validators: []validator.List{
+					listSizeAtLeast(1),
+					listElements(stringIsURL(routeToSchemes...)),
+					listSameURLScheme(),
+				},
 			},
 			// Allow SPDY protocol, optional field with default value
 			"allow_spdy": schema.BoolAttribute{
@@ -114,7 +149,7 @@ func (r *RouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
-				MarkdownDescription: "If set to `true`, allows WebSocket connections for this route.",
+				MarkdownDescription: "If set to `true`, allows WebSocket connections for this route. Defaults to `allow_websockets` in the provider's `route_defaults`, if set.",
 			},
 			// Enable Google Cloud Serverless Authentication, optional field with default value
 			"enable_google_cloud_serverless_authentication": schema.BoolAttribute{
@@ -127,14 +162,14 @@ func (r *RouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"pass_identity_headers": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "If set to `true`, passes identity headers to the upstream service.",
+				MarkdownDescription: "If set to `true`, passes identity headers to the upstream service. Defaults to `pass_identity_headers` in the provider's `route_defaults`, if set.",
 			},
 			// Preserve host header, optional field with default value
 			"preserve_host_header": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
-				MarkdownDescription: "If set to `true`, preserves the original host header when proxying requests.",
+				MarkdownDescription: "If set to `true`, preserves the original host header when proxying requests. Defaults to `preserve_host_header` in the provider's `route_defaults`, if set.",
 			},
 			// Show error details, optional field with default value
 			"show_error_details": schema.BoolAttribute{
@@ -161,11 +196,22 @@ func (r *RouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				MarkdownDescription: "TLS Downstream Server Name overrides the hostname specified in the from field.",
 			},
+			"tls_downstream_client_ca": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "One or more PEM encoded CA certificates, e.g. `file(\"client-ca.pem\")`. If set, clients of the route " +
+					"must present a certificate issued by one of these CAs, in addition to the downstream mTLS settings of the cluster.",
+				Validators: []validator.String{
+					stringIsPEMCertificates(),
+				},
+			},
 			// List of policy IDs associated with the route, optional field
 			"policy_ids": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
 				MarkdownDescription: "A list of policy IDs to associate with this route. These policies will be applied to requests matching this route.",
+				Validators: []validator.List{
+					listElements(stringIsID()),
+				},
 			},
 			// URL prefix for the route, optional field
 			"prefix": schema.StringAttribute{
@@ -179,14 +225,46 @@ func (r *RouteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			// Kubernetes service account token, optional field
 			"kubernetes_service_account_token": schema.StringAttribute{
-				Optional:            true,
-				Computed:            true,
-				MarkdownDescription: "The Kubernetes service account token to use for authentication.",
-				Sensitive:           true,
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The Kubernetes service account token to use for authentication. " +
+					"The token is stored in the Terraform state, so the state must be protected like the token itself.",
+				Sensitive: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			// Rotation trigger for the Kubernetes service account token, optional field
+			"kubernetes_service_account_token_version": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "An arbitrary value, such as a number or a date, to change when the Kubernetes service account token is rotated. " +
+					"The API may not return the token, so Terraform cannot detect that it was changed outside of Terraform; " +
+					"changing this value updates the route, which sends the configured token to Pomerium Zero again.",
+			},
+			// Deletion protection, optional field with default value
+			"deletion_protection": deletionProtectionAttribute("route"),
+			// Wait for deployment, optional field
+			"wait_for_deployment": waitForDeploymentAttribute("route"),
+			// Adoption of an existing route on creation, optional field
+			"adopt_existing": adoptExistingAttribute("route", "the same name or `from` URL"),
+			// Upsert by name on creation, optional field
+			"upsert": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "If set to `true`, creating the route updates the existing route with the same `name` in the namespace, if any, " +
+					"and only creates a route when there is none. Useful for pipelines generating routes from an external catalog, " +
+					"as existing routes do not need to be imported. Only has an effect on creation.",
+			},
+			// Externally reachable URL, automatically computed
+			"public_url": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The URL at which the route is reachable, for use by DNS records or uptime checks. " +
+					"It is the `from` URL with the `prefix` appended. When the host of `from` is a single label, such as `https://app`, " +
+					"the FQDN of the cluster of the namespace is appended to it.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			// Readiness probe of the from URL, optional block
+			"wait_for_ready": waitForReadyBlock(),
 		},
 	}
 }
@@ -210,9 +288,63 @@ func (r *RouteResource) Configure(_ context.Context, req resource.ConfigureReque
 	}
 
 	// Set the RouteResource fields with the provider's data
-	r.client = provider.client
-	r.token = provider.token
-	r.organizationID = provider.organizationID
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+	r.routeDefaults = provider.routeDefaultsConfig()
+}
+
+// ModifyPlan applies the route_defaults of the provider to the attributes that are not set
+// in the configuration of the route, checks that the policies are in the namespace of the route,
+// and keeps the public_url when the attributes it derives from do not change.
+func (r *RouteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to default when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.routeDefaults.apply(ctx, req.Config, &resp.Plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan RouteResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Policies of other namespaces are only rejected by the API on apply
+	if r.client != nil && !plan.NamespaceID.IsUnknown() && !plan.PolicyIDs.IsNull() && !plan.PolicyIDs.IsUnknown() {
+		var policyIDs []types.String
+		resp.Diagnostics.Append(plan.PolicyIDs.ElementsAs(ctx, &policyIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		knownIDs := make([]string, len(policyIDs))
+		for i, policyID := range policyIDs {
+			knownIDs[i] = policyID.ValueString()
+		}
+		resp.Diagnostics.Append(checkPolicyNamespaces(ctx, r.client, r.token, r.organizationID, plan.NamespaceID.ValueString(), knownIDs, func(i int) path.Path {
+			return path.Root("policy_ids").AtListIndex(i)
+		})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// The public URL only changes with the from URL, the prefix or the namespace
+	var state RouteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.From.Equal(state.From) && plan.Prefix.Equal(state.Prefix) && plan.NamespaceID.Equal(state.NamespaceID) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("public_url"), state.PublicURL)...)
+	}
 }
 
 // Create handles the creation of a new RouteResource
@@ -231,22 +363,57 @@ func (r *RouteResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Call the createRoute method to create the route in the external system
-	route, err := r.createRoute(ctx, &plan)
+	// Call the createRoute method to create the route in the external system, or update the
+	// route with the same name in upsert mode
+	var apiResponse map[string]interface{}
+	var err error
+	if plan.Upsert.ValueBool() {
+		apiResponse, err = r.upsertRoute(ctx, &plan)
+	} else {
+		apiResponse, err = r.createRoute(ctx, &plan)
+	}
+	if err != nil && isConflict(err) && plan.AdoptExisting.ValueBool() {
+		apiResponse, err = r.adoptRoute(ctx, &plan, err)
+	}
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError(
-			"Error creating route",
-			fmt.Sprintf("Could not create route, unexpected error: %s", err),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error creating route", fmt.Errorf("Could not create route, unexpected error: %w", err), req.Plan)...)
+		return
+	}
+
+	// Save the ID before anything else can fail, so that Terraform keeps track of the route that
+	// now exists, instead of creating a duplicate on the next apply
+	id, _ := apiResponse["id"].(string)
+	if id == "" {
+		resp.Diagnostics.AddError("Error creating route", fmt.Sprintf("The API did not return the ID of the created route: %v", apiResponse))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Set the state with the newly created route
+	route, diags := mapRouteResponseToModel(ctx, apiResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	preserveRouteLocalAttributes(&route, plan)
+	r.setPublicURL(ctx, &route)
 	diags = resp.State.Set(ctx, route)
 
 	// Append any diagnostics that occurred during state setting
 	resp.Diagnostics.Append(diags...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, inNamespace(plan.NamespaceID.ValueString()))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Probe the route until it responds, when requested
+	resp.Diagnostics.Append(waitForRouteReady(ctx, plan.WaitForReady, plan.From.ValueString(), plan.Prefix.ValueString())...)
 }
 
 // Read handles the reading of an existing RouteResource
@@ -266,13 +433,19 @@ func (r *RouteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Call the readRoute method to fetch the route from the external system
-	route, err := r.readRoute(ctx, state.ID.ValueString())
+	route, err := r.readRouteFromList(ctx, state.ID.ValueString())
 	if err != nil {
-		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError(
+		// If the route was deleted outside of Terraform, remove it from the state
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		// If there's any other error, add it to the diagnostics
+		resp.Diagnostics.Append(errorDiagnostics(
 			"Error Reading Route",
 			fmt.Sprintf("Could not read route ID %s: %s", state.ID.ValueString(), err),
-		)
+			err,
+		)...)
 		return
 	}
 
@@ -280,7 +453,13 @@ func (r *RouteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	log.Printf("[DEBUG] Raw API response for route %s: %+v", state.ID.ValueString(), route)
 
 	// Map the API response to our RouteResourceModel
-	newState := mapRouteResponseToModel(ctx, route)
+	newState, diags := mapRouteResponseToModel(ctx, route)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	preserveRouteLocalAttributes(&newState, state)
+	r.setPublicURL(ctx, &newState)
 
 	// Set the new state
 	diags = resp.State.Set(ctx, newState)
@@ -306,21 +485,34 @@ func (r *RouteResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Call the updateRoute method to update the route in the external system
-	route, err := r.updateRoute(ctx, &plan)
+	apiResponse, err := r.updateRoute(ctx, &plan)
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError(
-			"Error Updating Route",
-			fmt.Sprintf("Could not update route, unexpected error: %s", err),
-		)
+		resp.Diagnostics.Append(apiErrorDiagnostics(ctx, "Error Updating Route", fmt.Errorf("Could not update route, unexpected error: %w", err), req.Plan)...)
 		return
 	}
 
 	// Set the state with the updated route
+	route, diags := mapRouteResponseToModel(ctx, apiResponse)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	preserveRouteLocalAttributes(&route, plan)
+	r.setPublicURL(ctx, &route)
 	diags = resp.State.Set(ctx, route)
 
 	// Append any diagnostics that occurred during state setting
 	resp.Diagnostics.Append(diags...)
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, plan.WaitForDeployment, inNamespace(plan.NamespaceID.ValueString()))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Probe the route until it responds, when requested
+	resp.Diagnostics.Append(waitForRouteReady(ctx, plan.WaitForReady, plan.From.ValueString(), plan.Prefix.ValueString())...)
 }
 
 // Delete handles the deletion of a RouteResource
@@ -339,26 +531,78 @@ func (r *RouteResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Refuse to delete protected routes
+	resp.Diagnostics.Append(checkDeletionProtection(state.DeletionProtection, "route", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Call the deleteRoute method to delete the route in the external system
 	err := r.deleteRoute(ctx, state.ID.ValueString())
 	if err != nil {
 		// If there's an error, add it to the diagnostics
-		resp.Diagnostics.AddError(
+		resp.Diagnostics.Append(errorDiagnostics(
 			"Error Deleting Route",
 			fmt.Sprintf("Could not delete route, unexpected error: %s", err),
-		)
+			err,
+		)...)
 		return
 	}
-	// If we reach here, the route was successfully deleted
+
+	// Wait for the change to be deployed when requested, and warn when it is not live yet
+	resp.Diagnostics.Append(awaitDeployment(ctx, r.client, r.token, r.organizationID, state.WaitForDeployment, inNamespace(state.NamespaceID.ValueString()))...)
 }
 
-// ImportState handles the importing of an existing RouteResource
+// ImportState handles the importing of an existing RouteResource, by the route ID or by the namespace ID
+// and the route ID, separated by a slash. The namespace, when given, must be the one of the route.
+// It reads the full route, so that all attributes are populated, e.g. for terraform plan -generate-config-out.
 func (r *RouteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	namespaceID, routeID, composite := strings.Cut(req.ID, "/")
+	if !composite {
+		namespaceID, routeID = "", req.ID
+	}
+	if routeID == "" || (composite && namespaceID == "") || strings.Contains(routeID, "/") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID of the form <route_id> or <namespace_id>/<route_id>, got: %q", req.ID),
+		)
+		return
+	}
+
+	route, err := r.readRoute(ctx, routeID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing route", fmt.Sprintf("Unable to read route %s, error: %s", routeID, err))
+		return
+	}
+
+	state, diags := mapRouteResponseToModel(ctx, route)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if composite && state.NamespaceID.ValueString() != namespaceID {
+		resp.Diagnostics.AddError(
+			"Route In Another Namespace",
+			fmt.Sprintf("The route %s is in namespace %s, not in namespace %s of the import ID. "+
+				"Check the import ID, or import the route by its ID alone.", routeID, state.NamespaceID.ValueString(), namespaceID),
+		)
+		return
+	}
+	state.KubernetesServiceAccountTokenVersion = types.StringNull()
+	state.DeletionProtection = types.BoolValue(false)
+	state.WaitForReady = types.ObjectNull(waitForReadyAttrTypes)
+	r.setPublicURL(ctx, &state)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 }
 
-// createRoute creates a new route in the external system
-func (r *RouteResource) createRoute(ctx context.Context, plan *RouteResourceModel) (RouteResourceModel, error) {
+// createRoute creates a new route in the external system and returns the route as stored by the API
+func (r *RouteResource) createRoute(ctx context.Context, plan *RouteResourceModel) (map[string]interface{}, error) {
+	// Retries of the request must not create duplicate routes
+	ctx = withIdempotencyKey(ctx)
+
 	// Construct the URL for creating a route
 	url := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, r.organizationID)
 
@@ -366,16 +610,16 @@ func (r *RouteResource) createRoute(ctx context.Context, plan *RouteResourceMode
 	routeReq := createRouteRequest(plan)
 	body, err := json.Marshal(routeReq)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error marshaling route: %w", err)
+		return nil, fmt.Errorf("error marshaling route: %w", err)
 	}
 
-	// Log the request body for debugging
-	log.Printf("[DEBUG] Create route request body: %s", string(body))
+	// Log the request body for debugging, without the service account token
+	log.Printf("[DEBUG] Create route request body: %s", redactJSON(body))
 
 	// Create a new HTTP POST request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	// Set the necessary headers
@@ -385,32 +629,112 @@ func (r *RouteResource) createRoute(ctx context.Context, plan *RouteResourceMode
 	// Send the request
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error making request: %w", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read the response body
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error reading response body: %w", err)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	// Log the response for debugging
-	log.Printf("[DEBUG] Create route response status: %d, body: %s", resp.StatusCode, string(responseBody))
+	log.Printf("[DEBUG] Create route response status: %d, body: %s", resp.StatusCode, redactJSON(responseBody))
 
 	// Check if the status code indicates a successful creation
 	if resp.StatusCode != http.StatusCreated {
-		return RouteResourceModel{}, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp.StatusCode, responseBody)
 	}
 
 	// Unmarshal the response body into a map
 	var apiResponse map[string]interface{}
 	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error unmarshaling response: %w", err)
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
 	}
 
-	// Map the API response to our RouteResourceModel
-	return mapRouteResponseToModel(ctx, apiResponse), nil
+	return apiResponse, nil
+}
+
+// adoptRoute takes the route conflicting with the planned one under management, i.e. the route of the
+// namespace with the same name or from URL, and updates it to match the plan. The conflict is returned
+// when no such route exists.
+func (r *RouteResource) adoptRoute(ctx context.Context, plan *RouteResourceModel, conflict error) (map[string]interface{}, error) {
+	id, err := r.findRouteID(ctx, plan.NamespaceID.ValueString(), func(name, from string) bool {
+		return name == plan.Name.ValueString() || from == plan.From.ValueString()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the existing route to adopt: %w", err)
+	}
+	if id == "" {
+		return nil, conflict
+	}
+
+	log.Printf("[INFO] Adopting existing route %s", id)
+	plan.ID = types.StringValue(id)
+	return r.updateRoute(ctx, plan)
+}
+
+// upsertRoute updates the route of the namespace with the planned name, or creates the route when
+// there is none.
+func (r *RouteResource) upsertRoute(ctx context.Context, plan *RouteResourceModel) (map[string]interface{}, error) {
+	id, err := r.findRouteID(ctx, plan.NamespaceID.ValueString(), func(name, _ string) bool {
+		return name == plan.Name.ValueString()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the route to upsert: %w", err)
+	}
+	if id == "" {
+		return r.createRoute(ctx, plan)
+	}
+
+	log.Printf("[INFO] Updating existing route %s with the same name", id)
+	plan.ID = types.StringValue(id)
+	return r.updateRoute(ctx, plan)
+}
+
+// findRouteID returns the ID of the first route of the namespace whose name and from URL satisfy
+// match, or an empty string when there is none.
+func (r *RouteResource) findRouteID(ctx context.Context, namespaceID string, match func(name, from string) bool) (string, error) {
+	url := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, r.organizationID)
+
+	var routes []map[string]interface{}
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &routes); err != nil {
+		return "", fmt.Errorf("error listing routes: %w", err)
+	}
+
+	for _, route := range routes {
+		routeNamespaceID, _ := route["namespaceId"].(string)
+		name, _ := route["name"].(string)
+		from, _ := route["from"].(string)
+		if routeNamespaceID == namespaceID && match(name, from) {
+			id, _ := route["id"].(string)
+			return id, nil
+		}
+	}
+
+	return "", nil
+}
+
+// readRouteFromList looks the route up in the list of all routes, which is cached for the duration
+// of the operation, so that refreshing many routes lists them once. Routes missing from the list
+// are read individually, which also reports routes that no longer exist.
+func (r *RouteResource) readRouteFromList(ctx context.Context, id string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, r.organizationID)
+
+	var routes []map[string]interface{}
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &routes); err != nil {
+		log.Printf("[DEBUG] Could not list routes, reading route %s individually: %s", id, err)
+		return r.readRoute(ctx, id)
+	}
+
+	for _, route := range routes {
+		if routeID, _ := route["id"].(string); routeID == id {
+			return route, nil
+		}
+	}
+
+	return r.readRoute(ctx, id)
 }
 
 // readRoute fetches the details of a specific route from the API
@@ -434,6 +758,11 @@ func (r *RouteResource) readRoute(ctx context.Context, id string) (map[string]in
 	}
 	defer resp.Body.Close()
 
+	// The route may have been deleted outside of Terraform
+	if isNotFoundStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("GET %s: %w", url, errNotFound)
+	}
+
 	// Check if the response status code is OK (200)
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
@@ -450,7 +779,7 @@ func (r *RouteResource) readRoute(ctx context.Context, id string) (map[string]in
 }
 
 // updateRoute updates an existing route in the external system
-func (r *RouteResource) updateRoute(ctx context.Context, plan *RouteResourceModel) (RouteResourceModel, error) {
+func (r *RouteResource) updateRoute(ctx context.Context, plan *RouteResourceModel) (map[string]interface{}, error) {
 	// Construct the URL for updating a specific route
 	url := fmt.Sprintf("%s/organizations/%s/routes/%s", apiBaseURL, r.organizationID, plan.ID.ValueString())
 
@@ -458,13 +787,13 @@ func (r *RouteResource) updateRoute(ctx context.Context, plan *RouteResourceMode
 	routeReq := updateRouteRequest(plan)
 	body, err := json.Marshal(routeReq)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error marshaling route: %w", err)
+		return nil, fmt.Errorf("error marshaling route: %w", err)
 	}
 
 	// Create a new HTTP PUT request
 	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewBuffer(body))
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
 	// Set the necessary headers
@@ -474,29 +803,28 @@ func (r *RouteResource) updateRoute(ctx context.Context, plan *RouteResourceMode
 	// Send the request
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error making request: %w", err)
+		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read the response body
 	responseBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error reading response body: %w", err)
+		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
 
 	// Check if the status code indicates a successful update
 	if resp.StatusCode != http.StatusOK {
-		return RouteResourceModel{}, fmt.Errorf("unexpected status code: %d. Response body: %s", resp.StatusCode, string(responseBody))
+		return nil, newAPIError(resp.StatusCode, responseBody)
 	}
 
 	// Unmarshal the response body into a map
 	var apiResponse map[string]interface{}
 	if err := json.Unmarshal(responseBody, &apiResponse); err != nil {
-		return RouteResourceModel{}, fmt.Errorf("error unmarshaling response: %w", err)
+		return nil, fmt.Errorf("error unmarshaling response: %w", err)
 	}
 
-	// Map the API response to our RouteResourceModel and return it
-	return mapRouteResponseToModel(ctx, apiResponse), nil
+	return apiResponse, nil
 }
 
 // deleteRoute sends a DELETE request to remove a specific route from the Pomerium Zero API
@@ -583,6 +911,9 @@ func createRouteRequest(model *RouteResourceModel) map[string]interface{} {
 	if !model.TLSDownstreamServerName.IsNull() {
 		req["tlsDownstreamServerName"] = model.TLSDownstreamServerName.ValueString()
 	}
+	if !model.TLSDownstreamClientCA.IsNull() {
+		req["tlsDownstreamClientCa"] = model.TLSDownstreamClientCA.ValueString()
+	}
 
 	// Return the constructed request map
 	return req
@@ -594,63 +925,133 @@ func updateRouteRequest(model *RouteResourceModel) map[string]interface{} {
 	return createRouteRequest(model)
 }
 
-// mapRouteResponseToModel converts the API response to a RouteResourceModel
-func mapRouteResponseToModel(ctx context.Context, apiResponse map[string]interface{}) RouteResourceModel {
+// preserveRouteLocalAttributes copies the attributes that the API does not return from the prior model,
+// i.e. the plan or the state: the rotation trigger, deletion protection, and the service account token
+// when it was not returned.
+func preserveRouteLocalAttributes(model *RouteResourceModel, prior RouteResourceModel) {
+	model.KubernetesServiceAccountTokenVersion = prior.KubernetesServiceAccountTokenVersion
+	model.DeletionProtection = prior.DeletionProtection
+	model.WaitForDeployment = prior.WaitForDeployment
+	model.WaitForReady = prior.WaitForReady
+	model.AdoptExisting = prior.AdoptExisting
+	model.Upsert = prior.Upsert
+	if model.KubernetesServiceAccountToken.IsNull() && !prior.KubernetesServiceAccountToken.IsUnknown() {
+		model.KubernetesServiceAccountToken = prior.KubernetesServiceAccountToken
+	}
+}
+
+// mapRouteResponseToModel converts the API response to a RouteResourceModel. Missing and null fields
+// are mapped to null values, except for the fields that identify the route, which are required. Fields
+// of an unexpected type are reported as errors, instead of failing the whole provider.
+func mapRouteResponseToModel(_ context.Context, apiResponse map[string]interface{}) (RouteResourceModel, diag.Diagnostics) {
+	fields := routeResponseFields{response: apiResponse}
+
 	// Initialize the model with required string fields
 	model := RouteResourceModel{
-		ID:          types.StringValue(apiResponse["id"].(string)),
-		Name:        types.StringValue(apiResponse["name"].(string)),
-		NamespaceID: types.StringValue(apiResponse["namespaceId"].(string)),
-		From:        types.StringValue(apiResponse["from"].(string)),
+		ID:          fields.requiredString("id"),
+		Name:        fields.requiredString("name"),
+		NamespaceID: fields.requiredString("namespaceId"),
+		From:        fields.requiredString("from"),
+		To:          fields.stringList("to"),
 	}
 
-	// Handle the 'to' field, which is a list of strings
-	if to, ok := apiResponse["to"].([]interface{}); ok {
-		toList, _ := types.ListValueFrom(ctx, types.StringType, to)
-		model.To = toList
-	}
+	// Set boolean fields
+	model.AllowSpdy = fields.bool("allowSpdy")
+	model.AllowWebsockets = fields.bool("allowWebsockets")
+	model.EnableGoogleCloudServerlessAuthentication = fields.bool("enableGoogleCloudServerlessAuthentication")
+	model.PassIdentityHeaders = fields.bool("passIdentityHeaders")
+	model.PreserveHostHeader = fields.bool("preserveHostHeader")
+	model.ShowErrorDetails = fields.bool("showErrorDetails")
+	model.TLSSkipVerify = fields.bool("tlsSkipVerify")
+	model.TLSUpstreamAllowRenegotiation = fields.bool("tlsUpstreamAllowRenegotiation")
 
-	// Helper function to safely convert interface{} to bool
-	toBool := func(v interface{}) types.Bool {
-		if v == nil {
-			return types.BoolNull()
-		}
-		if b, ok := v.(bool); ok {
-			return types.BoolValue(b)
-		}
-		return types.BoolNull()
+	model.PolicyIDs = fields.stringList("policyIds")
+
+	// Handle optional string fields
+	model.Prefix = fields.string("prefix")
+	model.PrefixRewrite = fields.string("prefixRewrite")
+	model.KubernetesServiceAccountToken = fields.string("kubernetesServiceAccountToken")
+	model.TLSDownstreamServerName = fields.string("tlsDownstreamServerName")
+	model.TLSDownstreamClientCA = fields.string("tlsDownstreamClientCa")
+	if model.TLSDownstreamClientCA.ValueString() == "" {
+		model.TLSDownstreamClientCA = types.StringNull()
 	}
 
-	// Set boolean fields using the toBool helper function
-	model.AllowSpdy = toBool(apiResponse["allowSpdy"])
-	model.AllowWebsockets = toBool(apiResponse["allowWebsockets"])
-	model.EnableGoogleCloudServerlessAuthentication = toBool(apiResponse["enableGoogleCloudServerlessAuthentication"])
-	model.PassIdentityHeaders = toBool(apiResponse["passIdentityHeaders"])
-	model.PreserveHostHeader = toBool(apiResponse["preserveHostHeader"])
-	model.ShowErrorDetails = toBool(apiResponse["showErrorDetails"])
-	model.TLSSkipVerify = toBool(apiResponse["tlsSkipVerify"])
-	model.TLSUpstreamAllowRenegotiation = toBool(apiResponse["tlsUpstreamAllowRenegotiation"])
+	// Return the populated model
+	return model, fields.diags
+}
 
-	// Handle the 'policyIds' field, which is a list of strings
-	if policyIDs, ok := apiResponse["policyIds"].([]interface{}); ok {
-		policyIDsList, _ := types.ListValueFrom(ctx, types.StringType, policyIDs)
-		model.PolicyIDs = policyIDsList
-	}
+// routeResponseFields reads the fields of a route returned by the API, collecting an error for each
+// field that is missing when required or that has an unexpected type.
+type routeResponseFields struct {
+	response map[string]interface{}
+	diags    diag.Diagnostics
+}
 
-	// Handle optional string fields
-	if prefix, ok := apiResponse["prefix"].(string); ok {
-		model.Prefix = types.StringValue(prefix)
+// invalid records that the field does not hold what is expected.
+func (f *routeResponseFields) invalid(key, expected string) {
+	f.diags.AddError(
+		"Invalid Route Response",
+		fmt.Sprintf("The API returned a route whose field %q is not %s, got: %#v. Please report this issue to the provider developers.", key, expected, f.response[key]),
+	)
+}
+
+// requiredString returns a string field that must be set.
+func (f *routeResponseFields) requiredString(key string) types.String {
+	if f.response[key] == nil {
+		f.invalid(key, "set")
+		return types.StringNull()
 	}
-	if prefixRewrite, ok := apiResponse["prefixRewrite"].(string); ok {
-		model.PrefixRewrite = types.StringValue(prefixRewrite)
+	return f.string(key)
+}
+
+// string returns a string field, or null when it is missing or null.
+func (f *routeResponseFields) string(key string) types.String {
+	switch value := f.response[key].(type) {
+	case nil:
+		return types.StringNull()
+	case string:
+		return types.StringValue(value)
+	default:
+		f.invalid(key, "a string")
+		return types.StringNull()
 	}
-	if kubernetesServiceAccountToken, ok := apiResponse["kubernetesServiceAccountToken"].(string); ok {
-		model.KubernetesServiceAccountToken = types.StringValue(kubernetesServiceAccountToken)
+}
+
+// bool returns a boolean field, or null when it is missing or null.
+func (f *routeResponseFields) bool(key string) types.Bool {
+	switch value := f.response[key].(type) {
+	case nil:
+		return types.BoolNull()
+	case bool:
+		return types.BoolValue(value)
+	default:
+		f.invalid(key, "a boolean")
+		return types.BoolNull()
 	}
-	if tlsDownstreamServerName, ok := apiResponse["tlsDownstreamServerName"].(string); ok {
-		model.TLSDownstreamServerName = types.StringValue(tlsDownstreamServerName)
+}
+
+// stringList returns a field holding a list of strings, or null when it is missing or null.
+func (f *routeResponseFields) stringList(key string) types.List {
+	var elements []interface{}
+	switch value := f.response[key].(type) {
+	case nil:
+		return types.ListNull(types.StringType)
+	case []interface{}:
+		elements = value
+	default:
+		f.invalid(key, "a list of strings")
+		return types.ListNull(types.StringType)
 	}
 
-	// Return the populated model
-	return model
+	values := make([]attr.Value, 0, len(elements))
+	for _, element := range elements {
+		s, ok := element.(string)
+		if !ok {
+			f.invalid(key, "a list of strings")
+			return types.ListNull(types.StringType)
+		}
+		values = append(values, types.StringValue(s))
+	}
+	return types.ListValueMust(types.StringType, values)
 }
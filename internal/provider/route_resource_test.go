@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// validRouteResponse is a route as returned by the API, which test cases modify.
+const validRouteResponse = `{
+	"id": "rt-1",
+	"name": "app",
+	"namespaceId": "ns-1",
+	"from": "https://app.example.com",
+	"to": ["http://app.internal:8080"],
+	"allowSpdy": false,
+	"allowWebsockets": true,
+	"passIdentityHeaders": true,
+	"policyIds": ["pol-1"],
+	"prefix": "/api",
+	"tlsDownstreamClientCa": "",
+	"kubernetesServiceAccountToken": "sa-token"
+}`
+
+func TestMapRouteResponseToModel(t *testing.T) {
+	tests := []struct {
+		name string
+		// modify changes the valid route response
+		modify func(response map[string]interface{})
+		// response replaces the route response when set
+		response string
+		// wantErrors lists the fields reported as invalid
+		wantErrors []string
+		check      func(t *testing.T, model RouteResourceModel)
+	}{
+		{
+			name:   "valid route",
+			modify: func(map[string]interface{}) {},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if model.ID.ValueString() != "rt-1" || model.From.ValueString() != "https://app.example.com" {
+					t.Errorf("unexpected route %s from %s", model.ID, model.From)
+				}
+				want := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("http://app.internal:8080")})
+				if !model.To.Equal(want) {
+					t.Errorf("expected to %s, got %s", want, model.To)
+				}
+				if !model.AllowWebsockets.ValueBool() || model.AllowSpdy.ValueBool() {
+					t.Errorf("unexpected booleans: allow_websockets %s, allow_spdy %s", model.AllowWebsockets, model.AllowSpdy)
+				}
+				if !model.TLSDownstreamClientCA.IsNull() {
+					t.Errorf("expected an empty tls_downstream_client_ca to be null, got %s", model.TLSDownstreamClientCA)
+				}
+			},
+		},
+		{
+			name: "missing optional fields",
+			modify: func(response map[string]interface{}) {
+				for _, key := range []string{"to", "allowSpdy", "allowWebsockets", "passIdentityHeaders", "policyIds", "prefix", "kubernetesServiceAccountToken"} {
+					delete(response, key)
+				}
+			},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.To.IsNull() || !model.PolicyIDs.IsNull() {
+					t.Errorf("expected missing lists to be null, got to %s, policy_ids %s", model.To, model.PolicyIDs)
+				}
+				if !model.AllowSpdy.IsNull() || !model.AllowWebsockets.IsNull() || !model.PassIdentityHeaders.IsNull() {
+					t.Error("expected missing booleans to be null")
+				}
+				if !model.Prefix.IsNull() || !model.KubernetesServiceAccountToken.IsNull() {
+					t.Error("expected missing strings to be null")
+				}
+			},
+		},
+		{
+			name: "null optional fields",
+			modify: func(response map[string]interface{}) {
+				for _, key := range []string{"to", "allowWebsockets", "policyIds", "prefix", "prefixRewrite", "tlsDownstreamClientCa"} {
+					response[key] = nil
+				}
+			},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.To.IsNull() || !model.PolicyIDs.IsNull() || !model.AllowWebsockets.IsNull() ||
+					!model.Prefix.IsNull() || !model.PrefixRewrite.IsNull() || !model.TLSDownstreamClientCA.IsNull() {
+					t.Error("expected null fields to be null")
+				}
+				if !model.PassIdentityHeaders.ValueBool() {
+					t.Error("expected the other fields to be mapped")
+				}
+			},
+		},
+		{
+			name:       "missing id",
+			modify:     func(response map[string]interface{}) { delete(response, "id") },
+			wantErrors: []string{`"id"`},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.ID.IsNull() {
+					t.Errorf("expected a null id, got %s", model.ID)
+				}
+			},
+		},
+		{
+			name: "null required fields",
+			modify: func(response map[string]interface{}) {
+				response["name"] = nil
+				response["namespaceId"] = nil
+				response["from"] = nil
+			},
+			wantErrors: []string{`"name"`, `"namespaceId"`, `"from"`},
+		},
+		{
+			name:       "empty response",
+			response:   `{}`,
+			wantErrors: []string{`"id"`, `"name"`, `"namespaceId"`, `"from"`},
+		},
+		{
+			name:       "id of the wrong type",
+			modify:     func(response map[string]interface{}) { response["id"] = 42.0 },
+			wantErrors: []string{`"id"`},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.ID.IsNull() {
+					t.Errorf("expected a null id, got %s", model.ID)
+				}
+			},
+		},
+		{
+			name:       "to that is a string",
+			modify:     func(response map[string]interface{}) { response["to"] = "http://app.internal:8080" },
+			wantErrors: []string{`"to"`},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.To.IsNull() {
+					t.Errorf("expected a null to, got %s", model.To)
+				}
+			},
+		},
+		{
+			name: "to holding an object",
+			modify: func(response map[string]interface{}) {
+				response["to"] = []interface{}{"http://a", map[string]interface{}{"url": "http://b"}}
+			},
+			wantErrors: []string{`"to"`},
+		},
+		{
+			name:       "policy ids holding numbers",
+			modify:     func(response map[string]interface{}) { response["policyIds"] = []interface{}{1.0, 2.0} },
+			wantErrors: []string{`"policyIds"`},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.PolicyIDs.IsNull() {
+					t.Errorf("expected null policy_ids, got %s", model.PolicyIDs)
+				}
+			},
+		},
+		{
+			name:       "boolean that is a string",
+			modify:     func(response map[string]interface{}) { response["allowWebsockets"] = "true" },
+			wantErrors: []string{`"allowWebsockets"`},
+			check: func(t *testing.T, model RouteResourceModel) {
+				if !model.AllowWebsockets.IsNull() {
+					t.Errorf("expected a null allow_websockets, got %s", model.AllowWebsockets)
+				}
+			},
+		},
+		{
+			name:       "string that is an object",
+			modify:     func(response map[string]interface{}) { response["prefix"] = map[string]interface{}{"path": "/api"} },
+			wantErrors: []string{`"prefix"`},
+		},
+		{
+			name: "every field of the wrong type",
+			response: `{"id": 1, "name": true, "namespaceId": [], "from": {}, "to": 2, "allowSpdy": "no",
+				"policyIds": "pol-1", "prefix": false, "tlsDownstreamClientCa": 3}`,
+			wantErrors: []string{`"id"`, `"name"`, `"namespaceId"`, `"from"`, `"to"`, `"allowSpdy"`, `"policyIds"`, `"prefix"`, `"tlsDownstreamClientCa"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := validRouteResponse
+			if tt.response != "" {
+				payload = tt.response
+			}
+			var response map[string]interface{}
+			if err := json.Unmarshal([]byte(payload), &response); err != nil {
+				t.Fatal(err)
+			}
+			if tt.modify != nil {
+				tt.modify(response)
+			}
+
+			model, diags := mapRouteResponseToModel(context.Background(), response)
+
+			if got := diags.ErrorsCount(); got != len(tt.wantErrors) {
+				t.Errorf("expected %d errors, got %d: %v", len(tt.wantErrors), got, diags)
+			}
+			for _, field := range tt.wantErrors {
+				found := false
+				for _, d := range diags.Errors() {
+					if strings.Contains(d.Detail(), field) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Errorf("expected an error about the field %s, got: %v", field, diags)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, model)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// hostnamePattern matches a hostname made of one or more DNS labels.
+var hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RouteURLFunction{}
+
+// NewRouteURLFunction creates a new RouteURLFunction.
+func NewRouteURLFunction() function.Function {
+	return &RouteURLFunction{}
+}
+
+// RouteURLFunction builds the from URL of a route on a cluster domain.
+type RouteURLFunction struct{}
+
+// Metadata sets the name of the RouteURLFunction.
+func (f *RouteURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "route_url"
+}
+
+// Definition defines the parameters and return type of the RouteURLFunction.
+func (f *RouteURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the from URL of a route on a cluster domain",
+		MarkdownDescription: "Builds the canonical `from` URL of `pomeriumzero_route` for a subdomain of a cluster domain, " +
+			"e.g. `https://app.example.pomerium.app`. The domain and subdomain are lowercased and surrounding dots are removed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The cluster domain or FQDN, e.g. `fqdn` of `pomeriumzero_cluster`.",
+			},
+			function.StringParameter{
+				Name:                "subdomain",
+				MarkdownDescription: "The subdomain of the route, e.g. `app`. An empty string returns the URL of the domain itself.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the URL.
+func (f *RouteURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain, subdomain string
+	resp.Error = req.Arguments.Get(ctx, &domain, &subdomain)
+	if resp.Error != nil {
+		return
+	}
+
+	domain = strings.Trim(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "https://")), ".")
+	if !hostnamePattern.MatchString(domain) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid domain %q: must be a hostname such as \"example.pomerium.app\".", domain))
+		return
+	}
+
+	subdomain = strings.Trim(strings.ToLower(strings.TrimSpace(subdomain)), ".")
+	host := domain
+	if subdomain != "" {
+		if !hostnamePattern.MatchString(subdomain) {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid subdomain %q: must consist of DNS labels such as \"app\".", subdomain))
+			return
+		}
+		host = subdomain + "." + domain
+	}
+
+	resp.Error = resp.Result.Set(ctx, "https://"+host)
+}
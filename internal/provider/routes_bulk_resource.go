@@ -0,0 +1,610 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoutesBulkResource{}
+var _ resource.ResourceWithModifyPlan = &RoutesBulkResource{}
+var _ resource.ResourceWithValidateConfig = &RoutesBulkResource{}
+
+// NewRoutesBulkResource creates a new RoutesBulkResource.
+func NewRoutesBulkResource() resource.Resource {
+	return &RoutesBulkResource{}
+}
+
+// RoutesBulkResource defines the resource implementation.
+// It manages a set of routes described by a single JSON or YAML document, e.g. the routes
+// section of an open-source Pomerium configuration file.
+type RoutesBulkResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// RoutesBulkResourceModel describes the resource data model.
+type RoutesBulkResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	NamespaceID       types.String `tfsdk:"namespace_id"`
+	Routes            types.String `tfsdk:"routes"`
+	RouteIDs          types.Map    `tfsdk:"route_ids"`
+	RouteFingerprints types.Map    `tfsdk:"route_fingerprints"`
+}
+
+// bulkRoute is a single route of the routes document, keyed by its name.
+type bulkRoute struct {
+	name    string
+	request map[string]interface{}
+}
+
+// Metadata sets the resource type name for the RoutesBulkResource.
+func (r *RoutesBulkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_routes_bulk"
+}
+
+// Schema defines the structure and attributes of the RoutesBulkResource.
+func (r *RoutesBulkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a set of routes in a Pomerium Zero namespace from a single JSON or YAML document, " +
+			"e.g. the routes of an open-source Pomerium configuration file. Routes are matched by name, " +
+			"created, updated and deleted to converge on the document. When a route cannot be created or updated, " +
+			"the changes already made in the same apply are rolled back and an error is reported for every failing route.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource. This corresponds to the namespace ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the namespace the routes are created in.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"routes": schema.StringAttribute{
+				MarkdownDescription: "A JSON or YAML document holding either a list of routes, or an object with a `routes` key holding the list, " +
+					"like an open-source Pomerium configuration file. Route fields may be written in snake case (`allow_websockets`) " +
+					"or camel case (`allowWebsockets`). Every route needs a `from` URL. Routes without a `name` are named after " +
+					"their `from` URL and `prefix` or `path`. Inline `policy` blocks are not supported; reference policies with `policy_ids` instead. " +
+					yamlSubsetDescription,
+				Required: true,
+			},
+			"route_ids": schema.MapAttribute{
+				MarkdownDescription: "The IDs of the managed routes, keyed by route name.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"route_fingerprints": schema.MapAttribute{
+				MarkdownDescription: "Checksums of the managed routes, keyed by route name. Used to detect routes changed outside of Terraform.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig parses the routes document, so that invalid routes are reported at plan time.
+func (r *RoutesBulkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var routes types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("routes"), &routes)...)
+	if resp.Diagnostics.HasError() || routes.IsNull() || routes.IsUnknown() {
+		return
+	}
+
+	_, diags := parseBulkRoutes(routes.ValueString(), "")
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure prepares a Pomerium Zero API client for the RoutesBulkResource.
+func (r *RoutesBulkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// ModifyPlan computes the fingerprints of the planned routes, so that routes changed outside of
+// Terraform, or removed from the document, show up as a difference.
+func (r *RoutesBulkResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan RoutesBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Routes.IsUnknown() || plan.NamespaceID.IsUnknown() {
+		return
+	}
+
+	routes, diags := parseBulkRoutes(plan.Routes.ValueString(), plan.NamespaceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Policies of other namespaces are only rejected by the API on apply
+	if r.client != nil {
+		for _, route := range routes {
+			policyIDs, _ := route.request["policyIds"].([]interface{})
+			ids := make([]string, 0, len(policyIDs))
+			for _, policyID := range policyIDs {
+				if id, ok := policyID.(string); ok {
+					ids = append(ids, id)
+				}
+			}
+			resp.Diagnostics.Append(checkPolicyNamespaces(ctx, r.client, r.token, r.organizationID, plan.NamespaceID.ValueString(), ids, func(int) path.Path {
+				return path.Root("routes")
+			})...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	fingerprints := make(map[string]string, len(routes))
+	for _, route := range routes {
+		fingerprints[route.name] = bulkRouteFingerprint(route.request, route.request)
+	}
+	fingerprintsValue, diags := types.MapValueFrom(ctx, types.StringType, fingerprints)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("route_fingerprints"), fingerprintsValue)...)
+
+	// The IDs only change when routes are added or removed
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state RoutesBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDs := make(map[string]string)
+	resp.Diagnostics.Append(state.RouteIDs.ElementsAs(ctx, &routeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDsValue := types.MapUnknown(types.StringType)
+	if len(routeIDs) == len(fingerprints) && bulkRouteNamesMatch(routeIDs, fingerprints) {
+		routeIDsValue = state.RouteIDs
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("route_ids"), routeIDsValue)...)
+}
+
+// Create creates all routes of the document.
+func (r *RoutesBulkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoutesBulkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routes, diags := parseBulkRoutes(plan.Routes.ValueString(), plan.NamespaceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDs, fingerprints, applied := r.applyRoutes(ctx, routes, map[string]string{}, map[string]string{}, &resp.Diagnostics)
+	if !applied {
+		return
+	}
+
+	plan.ID = plan.NamespaceID
+	resp.Diagnostics.Append(setBulkRouteMaps(ctx, &plan, routeIDs, fingerprints)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the managed routes. Routes deleted outside of Terraform are dropped from the state,
+// so they are created again on the next apply.
+func (r *RoutesBulkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoutesBulkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDs := make(map[string]string)
+	resp.Diagnostics.Append(state.RouteIDs.ElementsAs(ctx, &routeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The fingerprints only cover the fields set in the document
+	desired := make(map[string]map[string]interface{})
+	if routes, diags := parseBulkRoutes(state.Routes.ValueString(), state.NamespaceID.ValueString()); !diags.HasError() {
+		for _, route := range routes {
+			desired[route.name] = route.request
+		}
+	}
+
+	fingerprints := make(map[string]string, len(routeIDs))
+	for name, id := range routeIDs {
+		route, err := r.getRoute(ctx, id)
+		if err != nil {
+			if errors.Is(err, errNotFound) {
+				delete(routeIDs, name)
+				continue
+			}
+			resp.Diagnostics.AddError("Error reading route", fmt.Sprintf("Unable to read route %q (%s), error: %s", name, id, err))
+			return
+		}
+		fields := desired[name]
+		if fields == nil {
+			fields = route
+		}
+		fingerprints[name] = bulkRouteFingerprint(route, fields)
+	}
+
+	resp.Diagnostics.Append(setBulkRouteMaps(ctx, &state, routeIDs, fingerprints)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update converges the managed routes on the document.
+func (r *RoutesBulkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state RoutesBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routes, diags := parseBulkRoutes(plan.Routes.ValueString(), plan.NamespaceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorIDs := make(map[string]string)
+	priorFingerprints := make(map[string]string)
+	resp.Diagnostics.Append(state.RouteIDs.ElementsAs(ctx, &priorIDs, false)...)
+	resp.Diagnostics.Append(state.RouteFingerprints.ElementsAs(ctx, &priorFingerprints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDs, fingerprints, applied := r.applyRoutes(ctx, routes, priorIDs, priorFingerprints, &resp.Diagnostics)
+	if !applied {
+		// Everything was rolled back, so the prior state still holds
+		resp.State.Raw = req.State.Raw
+		return
+	}
+
+	resp.Diagnostics.Append(setBulkRouteMaps(ctx, &plan, routeIDs, fingerprints)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes all managed routes.
+func (r *RoutesBulkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoutesBulkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	routeIDs := make(map[string]string)
+	resp.Diagnostics.Append(state.RouteIDs.ElementsAs(ctx, &routeIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name, id := range routeIDs {
+		if err := r.deleteRoute(ctx, id); err != nil && !errors.Is(err, errNotFound) {
+			resp.Diagnostics.AddError("Error deleting route", fmt.Sprintf("Unable to delete route %q (%s), error: %s", name, id, err))
+		}
+	}
+}
+
+// applyRoutes creates and updates the routes of the document, and then deletes the routes that are no
+// longer in the document. When a route cannot be created or updated, the other changes are rolled back
+// and applied is false. Deletions are not rolled back: routes that could not be deleted are kept in the
+// returned IDs, so deleting them is retried on the next apply.
+func (r *RoutesBulkResource) applyRoutes(ctx context.Context, routes []bulkRoute, priorIDs, priorFingerprints map[string]string, diags *diag.Diagnostics) (map[string]string, map[string]string, bool) {
+	routeIDs := make(map[string]string, len(routes))
+	fingerprints := make(map[string]string, len(routes))
+	var rollback []func() error
+	failed := false
+
+	for _, route := range routes {
+		fingerprint := bulkRouteFingerprint(route.request, route.request)
+		id, exists := priorIDs[route.name]
+
+		// Unchanged routes are left alone
+		if exists && priorFingerprints[route.name] == fingerprint {
+			routeIDs[route.name] = id
+			fingerprints[route.name] = fingerprint
+			continue
+		}
+
+		var previous map[string]interface{}
+		if exists {
+			var err error
+			previous, err = r.getRoute(ctx, id)
+			if errors.Is(err, errNotFound) {
+				exists = false
+			} else if err != nil {
+				diags.AddAttributeError(path.Root("routes"), "Error Updating Route", fmt.Sprintf("Unable to read route %q (%s), error: %s", route.name, id, err))
+				failed = true
+				continue
+			}
+		}
+
+		if exists {
+			if _, err := r.putRoute(ctx, id, route.request); err != nil {
+				diags.AddAttributeError(path.Root("routes"), "Error Updating Route", fmt.Sprintf("Unable to update route %q (%s), error: %s", route.name, id, err))
+				failed = true
+				continue
+			}
+			rollback = append(rollback, func() error {
+				_, err := r.putRoute(ctx, id, previous)
+				return err
+			})
+		} else {
+			created, err := r.createRoute(ctx, route.request)
+			if err != nil {
+				diags.AddAttributeError(path.Root("routes"), "Error Creating Route", fmt.Sprintf("Unable to create route %q, error: %s", route.name, err))
+				failed = true
+				continue
+			}
+			id, _ = created["id"].(string)
+			rollback = append(rollback, func() error {
+				return r.deleteRoute(ctx, id)
+			})
+		}
+
+		routeIDs[route.name] = id
+		fingerprints[route.name] = fingerprint
+	}
+
+	if failed {
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if err := rollback[i](); err != nil {
+				diags.AddError("Error Rolling Back Route Changes", fmt.Sprintf("Unable to roll back a route change, the routes may need to be fixed manually: %s", err))
+			}
+		}
+		return nil, nil, false
+	}
+
+	for name, id := range priorIDs {
+		if _, wanted := routeIDs[name]; wanted {
+			continue
+		}
+		if err := r.deleteRoute(ctx, id); err != nil && !errors.Is(err, errNotFound) {
+			diags.AddAttributeError(path.Root("routes"), "Error Deleting Route", fmt.Sprintf("Unable to delete route %q (%s), error: %s", name, id, err))
+			routeIDs[name] = id
+			fingerprints[name] = priorFingerprints[name]
+		}
+	}
+
+	return routeIDs, fingerprints, true
+}
+
+// getRoute fetches a route by its ID.
+func (r *RoutesBulkResource) getRoute(ctx context.Context, id string) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/organizations/%s/routes/%s", apiBaseURL, r.organizationID, id)
+
+	var route map[string]interface{}
+	if err := doAPIRequest(ctx, r.client, r.token, "GET", url, nil, http.StatusOK, &route); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// createRoute creates a route and returns the route as stored by the API.
+func (r *RoutesBulkResource) createRoute(ctx context.Context, request map[string]interface{}) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, r.organizationID)
+
+	// Retries of the request must not create duplicate routes
+	ctx = withIdempotencyKey(ctx)
+
+	var route map[string]interface{}
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, request, http.StatusCreated, &route); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// putRoute replaces a route and returns the route as stored by the API.
+func (r *RoutesBulkResource) putRoute(ctx context.Context, id string, request map[string]interface{}) (map[string]interface{}, error) {
+	url := fmt.Sprintf("%s/organizations/%s/routes/%s", apiBaseURL, r.organizationID, id)
+
+	var route map[string]interface{}
+	if err := doAPIRequest(ctx, r.client, r.token, "PUT", url, request, http.StatusOK, &route); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// deleteRoute deletes a route by its ID.
+func (r *RoutesBulkResource) deleteRoute(ctx context.Context, id string) error {
+	url := fmt.Sprintf("%s/organizations/%s/routes/%s", apiBaseURL, r.organizationID, id)
+	return doAPIRequest(ctx, r.client, r.token, "DELETE", url, nil, http.StatusNoContent, nil)
+}
+
+// parseBulkRoutes parses a JSON or YAML routes document into route API requests for the namespace.
+// Every invalid route is reported as a separate diagnostic.
+func parseBulkRoutes(document, namespaceID string) ([]bulkRoute, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var decoded interface{}
+	var err error
+	if trimmed := strings.TrimSpace(document); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal([]byte(trimmed), &decoded)
+	} else {
+		decoded, err = decodeYAML([]byte(document))
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("routes"), "Invalid Routes Document", fmt.Sprintf("The routes document is not valid JSON or YAML: %s", err))
+		return nil, diags
+	}
+
+	if config, ok := decoded.(map[string]interface{}); ok {
+		decoded = config["routes"]
+	}
+	items, ok := decoded.([]interface{})
+	if !ok {
+		diags.AddAttributeError(path.Root("routes"), "Invalid Routes Document", "The routes document must hold a list of routes, or an object with a routes key holding the list.")
+		return nil, diags
+	}
+
+	routes := make([]bulkRoute, 0, len(items))
+	seen := make(map[string]int, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			diags.AddAttributeError(path.Root("routes"), "Invalid Route", fmt.Sprintf("Route %d must be an object, got: %v", i+1, item))
+			continue
+		}
+
+		request := make(map[string]interface{}, len(fields)+1)
+		for key, value := range fields {
+			request[snakeToCamelCase(key)] = value
+		}
+
+		from, _ := request["from"].(string)
+		if from == "" {
+			diags.AddAttributeError(path.Root("routes"), "Invalid Route", fmt.Sprintf("Route %d must have a from URL.", i+1))
+			continue
+		}
+
+		name, _ := request["name"].(string)
+		if name == "" {
+			name = from
+			for _, key := range []string{"prefix", "path"} {
+				if suffix, ok := request[key].(string); ok {
+					name += suffix
+				}
+			}
+			request["name"] = name
+		}
+
+		if _, ok := request["policy"]; ok {
+			diags.AddAttributeError(path.Root("routes"), "Invalid Route", fmt.Sprintf("Route %q has an inline policy, which is not supported. "+
+				"Create the policy with a pomeriumzero_policy resource and reference it with policy_ids instead.", name))
+			continue
+		}
+		if _, ok := request["id"]; ok {
+			diags.AddAttributeError(path.Root("routes"), "Invalid Route", fmt.Sprintf("Route %q has an id, which is assigned by the API.", name))
+			continue
+		}
+
+		if first, ok := seen[name]; ok {
+			diags.AddAttributeError(path.Root("routes"), "Duplicate Route", fmt.Sprintf("Routes %d and %d are both named %q. Route names must be unique.", first, i+1, name))
+			continue
+		}
+		seen[name] = i + 1
+
+		if namespaceID != "" {
+			request["namespaceId"] = namespaceID
+		}
+
+		routes = append(routes, bulkRoute{name: name, request: request})
+	}
+
+	return routes, diags
+}
+
+// bulkRouteFingerprint returns a checksum of the route, only taking the fields set in fields into account.
+// Fields that the route does not have are taken from fields, so values the API omits do not cause a difference.
+func bulkRouteFingerprint(route, fields map[string]interface{}) string {
+	projected := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if routeValue, ok := route[key]; ok {
+			value = routeValue
+		}
+		projected[key] = value
+	}
+
+	// Maps are marshaled with sorted keys, which makes the checksum stable
+	data, _ := json.Marshal(projected)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// bulkRouteNamesMatch reports whether both maps have the same keys.
+func bulkRouteNamesMatch(a, b map[string]string) bool {
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+// setBulkRouteMaps stores the route IDs and fingerprints in the model.
+func setBulkRouteMaps(ctx context.Context, model *RoutesBulkResourceModel, routeIDs, fingerprints map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ids, d := types.MapValueFrom(ctx, types.StringType, routeIDs)
+	diags.Append(d...)
+	model.RouteIDs = ids
+
+	checksums, d := types.MapValueFrom(ctx, types.StringType, fingerprints)
+	diags.Append(d...)
+	model.RouteFingerprints = checksums
+
+	return diags
+}
+
+// snakeToCamelCase converts a snake case field name, as used in open-source Pomerium configuration
+// files, to the camel case used by the Pomerium Zero API. Camel case names are returned unchanged.
+func snakeToCamelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// sampleRateTolerance is the largest difference between two sample rates that is considered noise.
+// Sample rates range from 0 to 1, so any meaningful difference is orders of magnitude larger.
+const sampleRateTolerance = 1e-9
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.Float64Typable                    = sampleRateType{}
+	_ basetypes.Float64ValuableWithSemanticEquals = sampleRateValue{}
+)
+
+// sampleRateType is the type of float attributes holding a sample rate, such as `0.1`. The API
+// stores sample rates with a different precision, e.g. it returns `0.10000000000000001` for `0.1`,
+// so values of this type are equal when they differ by no more than sampleRateTolerance.
+type sampleRateType struct {
+	basetypes.Float64Type
+}
+
+// String returns a human readable name of the type.
+func (t sampleRateType) String() string {
+	return "sampleRateType"
+}
+
+// Equal reports whether o is also a sampleRateType.
+func (t sampleRateType) Equal(o attr.Type) bool {
+	other, ok := o.(sampleRateType)
+	if !ok {
+		return false
+	}
+	return t.Float64Type.Equal(other.Float64Type)
+}
+
+// ValueType returns the value type of this type.
+func (t sampleRateType) ValueType(_ context.Context) attr.Value {
+	return sampleRateValue{}
+}
+
+// ValueFromFloat64 converts a float value to a sampleRateValue.
+func (t sampleRateType) ValueFromFloat64(_ context.Context, in basetypes.Float64Value) (basetypes.Float64Valuable, diag.Diagnostics) {
+	return sampleRateValue{Float64Value: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value to a sampleRateValue.
+func (t sampleRateType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.Float64Type.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	floatValue, ok := attrValue.(basetypes.Float64Value)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return sampleRateValue{Float64Value: floatValue}, nil
+}
+
+// sampleRateValue is a value of sampleRateType.
+type sampleRateValue struct {
+	basetypes.Float64Value
+}
+
+// newSampleRateValue returns a known sample rate.
+func newSampleRateValue(f float64) sampleRateValue {
+	return sampleRateValue{Float64Value: basetypes.NewFloat64Value(f)}
+}
+
+// Type returns the type of the value.
+func (v sampleRateValue) Type(_ context.Context) attr.Type {
+	return sampleRateType{}
+}
+
+// Equal reports whether o is a sampleRateValue with exactly the same value. Use Float64SemanticEquals
+// to ignore differences in precision.
+func (v sampleRateValue) Equal(o attr.Value) bool {
+	other, ok := o.(sampleRateValue)
+	if !ok {
+		return false
+	}
+	return v.Float64Value.Equal(other.Float64Value)
+}
+
+// Float64SemanticEquals reports whether both sample rates differ by no more than sampleRateTolerance.
+// Terraform then keeps the value as written in the configuration, instead of reporting the value
+// returned by the API as a change.
+func (v sampleRateValue) Float64SemanticEquals(_ context.Context, newValuable basetypes.Float64Valuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(sampleRateValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return math.Abs(v.ValueFloat64()-newValue.ValueFloat64()) <= sampleRateTolerance, diags
+}
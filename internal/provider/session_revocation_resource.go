@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SessionRevocationResource{}
+
+// NewSessionRevocationResource creates a new SessionRevocationResource.
+func NewSessionRevocationResource() resource.Resource {
+	return &SessionRevocationResource{}
+}
+
+// SessionRevocationResource defines the resource implementation.
+// Creating the resource revokes the active sessions of a cluster, or of a single user on the cluster.
+type SessionRevocationResource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// SessionRevocationResourceModel describes the resource data model.
+type SessionRevocationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ClusterID    types.String `tfsdk:"cluster_id"`
+	UserID       types.String `tfsdk:"user_id"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	RevokedCount types.Int64  `tfsdk:"revoked_count"`
+	RevokedAt    types.String `tfsdk:"revoked_at"`
+}
+
+// Metadata sets the resource type name for the SessionRevocationResource.
+func (r *SessionRevocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_revocation"
+}
+
+// Schema defines the structure and attributes of the SessionRevocationResource.
+func (r *SessionRevocationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Revokes the active sessions of a Pomerium Zero cluster, or of a single user on the cluster, " +
+			"forcing the affected users to sign in again. Sessions are revoked when the resource is created, " +
+			"and again whenever `triggers` change. Destroying the resource does not restore any sessions.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the resource. This corresponds to the cluster ID, followed by the user ID if set.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster whose sessions are revoked.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user whose sessions are revoked. When not set, the sessions of all users are revoked.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause the sessions to be revoked again when changed, e.g. an incident ticket number.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"revoked_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of sessions that were revoked.",
+				Computed:            true,
+			},
+			"revoked_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp at which the sessions were revoked.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the SessionRevocationResource.
+func (r *SessionRevocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client, r.token, r.organizationID = provider.apiCredentials()
+}
+
+// Create revokes the sessions.
+func (r *SessionRevocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SessionRevocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters/%s/sessions/revoke", apiBaseURL, r.organizationID, plan.ClusterID.ValueString())
+	body := struct {
+		UserID string `json:"userId,omitempty"`
+	}{
+		UserID: plan.UserID.ValueString(),
+	}
+
+	var revocation SessionRevocation
+	if err := doAPIRequest(ctx, r.client, r.token, "POST", url, body, http.StatusOK, &revocation); err != nil {
+		resp.Diagnostics.AddError("Error revoking sessions", err.Error())
+		return
+	}
+
+	plan.ID = plan.ClusterID
+	if !plan.UserID.IsNull() {
+		plan.ID = types.StringValue(plan.ClusterID.ValueString() + "/" + plan.UserID.ValueString())
+	}
+	plan.RevokedCount = types.Int64Value(revocation.RevokedCount)
+	plan.RevokedAt = types.StringValue(revocation.RevokedAt)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the state as is, since a revocation is a one-off operation that cannot be read back.
+func (r *SessionRevocationResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update is never called, since every attribute change replaces the resource.
+func (r *SessionRevocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SessionRevocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state. Revoked sessions are not restored.
+func (r *SessionRevocationResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
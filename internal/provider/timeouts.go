@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// timeoutsModel describes the data model of the timeouts block.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsAttrTypes are the attribute types of the timeouts block, needed to build null values
+// for it, e.g. when importing a resource.
+var timeoutsAttrTypes = map[string]attr.Type{
+	"create": types.StringType,
+	"read":   types.StringType,
+	"update": types.StringType,
+	"delete": types.StringType,
+}
+
+// timeoutsBlock returns the schema of the standard timeouts block, which lets users override
+// the deadline of each operation of a resource.
+func timeoutsBlock() resource_schema.Block {
+	attribute := func(operation string) resource_schema.StringAttribute {
+		return resource_schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "How long to wait for the " + operation + " operation to complete, as a duration such as `30s` or `10m`.",
+			Validators: []validator.String{
+				stringIsDuration(),
+			},
+		}
+	}
+
+	return resource_schema.SingleNestedBlock{
+		MarkdownDescription: "Overrides the default deadlines of the operations on this resource.",
+		Attributes: map[string]resource_schema.Attribute{
+			"create": attribute("create"),
+			"read":   attribute("read"),
+			"update": attribute("update"),
+			"delete": attribute("delete"),
+		},
+	}
+}
+
+// operationTimeout returns the timeout configured for the given operation in the timeouts
+// block, or defaultTimeout when the block or the operation's attribute is not set.
+func operationTimeout(ctx context.Context, timeouts types.Object, operation string, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
+	if timeouts.IsNull() || timeouts.IsUnknown() {
+		return defaultTimeout, nil
+	}
+
+	var model timeoutsModel
+	diags := timeouts.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return defaultTimeout, diags
+	}
+
+	var value types.String
+	switch operation {
+	case "create":
+		value = model.Create
+	case "read":
+		value = model.Read
+	case "update":
+		value = model.Update
+	case "delete":
+		value = model.Delete
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return defaultTimeout, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Timeout", "Unable to parse the "+operation+" timeout: "+err.Error())
+		return defaultTimeout, diags
+	}
+
+	return timeout, diags
+}
+
+// withOperationTimeout returns a context limited to the timeout of an operation. The requests of the
+// operation are not limited to the request timeout of the provider, which would be shorter than the
+// timeouts block allows.
+func withOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(withoutAttemptTimeout(ctx), timeout)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenRefreshMargin is how long before its expiry a bearer token is replaced, so that requests
+// sent just before it expires do not reach the API with an expired token.
+const tokenRefreshMargin = time.Minute
+
+// tokenRefreshTransport keeps the bearer token of the provider valid during long operations, e.g.
+// applies of hundreds of routes. Resources and data sources keep sending the token obtained in
+// Configure, which it replaces with the current one, exchanging the credentials for a new token
+// when it is about to expire or the API rejects it.
+type tokenRefreshTransport struct {
+	next http.RoundTripper
+
+	// exchangeMu serializes exchanges, so that concurrent requests that find the token expired
+	// exchange the credentials once
+	exchangeMu sync.Mutex
+	// mu guards the fields below
+	mu       sync.Mutex
+	initial  string
+	current  string
+	expiry   time.Time
+	exchange func(ctx context.Context) (string, error)
+}
+
+// start sets the token obtained in Configure, and the function exchanging the credentials for a new one.
+func (t *tokenRefreshTransport) start(token string, exchange func(ctx context.Context) (string, error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.initial = token
+	t.current = token
+	t.expiry = tokenExpiry(token)
+	t.exchange = exchange
+}
+
+// RoundTrip sends requests authenticated with the token of the provider with the current token, and
+// resends them once with a new token when the API responds with 401 Unauthorized.
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sent, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok || sent == "" {
+		return t.next.RoundTrip(req)
+	}
+	t.mu.Lock()
+	managed := (sent == t.initial || sent == t.current) && t.exchange != nil
+	t.mu.Unlock()
+	if !managed {
+		return t.next.RoundTrip(req)
+	}
+
+	token, err := t.token(req.Context(), "")
+	if err != nil {
+		// The current token may still be accepted
+		log.Printf("[WARN] Unable to renew the bearer token before it expires: %v", err)
+	}
+	resp, err := t.next.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Requests whose body was consumed cannot be resent
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	renewed, err := t.token(req.Context(), token)
+	if err != nil {
+		log.Printf("[WARN] Unable to renew the bearer token rejected by the API: %v", err)
+		return resp, nil
+	}
+
+	retry := withBearerToken(req, renewed)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	log.Printf("[DEBUG] Resending %s %s with a renewed bearer token", req.Method, req.URL.Path)
+	return t.next.RoundTrip(retry)
+}
+
+// token returns the current token. The credentials are exchanged for a new one when it is about to
+// expire, or when it is the token that the API rejected.
+func (t *tokenRefreshTransport) token(ctx context.Context, rejected string) (string, error) {
+	current, renew := t.state(rejected)
+	if !renew {
+		return current, nil
+	}
+
+	t.exchangeMu.Lock()
+	defer t.exchangeMu.Unlock()
+
+	// Another request may have renewed the token while this one waited
+	current, renew = t.state(rejected)
+	if !renew {
+		return current, nil
+	}
+
+	log.Println("Exchanging the credentials for a new bearer token")
+	token, err := t.exchange(ctx)
+	if err != nil {
+		return current, err
+	}
+
+	t.mu.Lock()
+	t.current = token
+	t.expiry = tokenExpiry(token)
+	t.mu.Unlock()
+	return token, nil
+}
+
+// state returns the current token, and whether it must be renewed because it is about to expire
+// or was rejected.
+func (t *tokenRefreshTransport) state(rejected string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	expiring := !t.expiry.IsZero() && time.Until(t.expiry) < tokenRefreshMargin
+	return t.current, t.current == rejected || expiring
+}
+
+// withBearerToken returns a copy of the request authenticated with the token.
+func withBearerToken(req *http.Request, token string) *http.Request {
+	// A RoundTripper must not modify the request it was given
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// tokenExpiry returns the expiry time of a JWT, or the zero time when it has none or is not a JWT.
+// The token is not verified, which is left to the API.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(claims.Exp), 0)
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// raceTestTokenLifetime is how long the API accepts the tokens it issues, short enough for them to
+	// expire while the requests of the test are in flight.
+	raceTestTokenLifetime = 50 * time.Millisecond
+	// raceTestOrganization is the organization that the requests of the test are made for.
+	raceTestOrganization = "org-1"
+)
+
+// raceTestAPI is a fake Pomerium Zero API, which issues short-lived tokens, announces a varying rate
+// limit, and fails some requests with transient errors.
+type raceTestAPI struct {
+	mu sync.Mutex
+	// attempts counts all requests received, including those resent by the provider
+	attempts int
+	// exchanges counts the tokens issued
+	exchanges int
+	// tokens holds the expiry of each token issued
+	tokens map[string]time.Time
+	// routes holds the IDs of the routes created, in order
+	routes []string
+	// idempotencyKeys holds the ID of the route created for each idempotency key
+	idempotencyKeys map[string]string
+}
+
+// ServeHTTP answers a request to the API.
+func (a *raceTestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.attempts++
+
+	// Announce a depleting rate limit, and exhaust it now and then. Requests are not held back, as
+	// the limit resets right away, so that delays do not outlast the tokens.
+	w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(1000-a.attempts%500))
+	w.Header().Set("X-RateLimit-Reset", "0")
+	w.Header().Set("Content-Type", "application/json")
+	if a.attempts%25 == 0 {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v0")
+	if r.Method == http.MethodPost && path == "/token" {
+		if !strings.Contains(string(body), "api-token") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		a.exchanges++
+		expiry := time.Now().Add(raceTestTokenLifetime)
+		// The token claims to expire later, so that the provider renews it as the API stops accepting it
+		token := raceTestJWT(a.exchanges, expiry.Add(tokenRefreshMargin))
+		a.tokens[token] = expiry
+		_ = json.NewEncoder(w).Encode(map[string]string{"idToken": token})
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if expiry, ok := a.tokens[token]; !ok || time.Now().After(expiry) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	routes := "/organizations/" + raceTestOrganization + "/routes"
+	switch {
+	case r.Method == http.MethodGet && path == routes:
+		list := make([]map[string]string, 0, len(a.routes))
+		for _, id := range a.routes {
+			list = append(list, map[string]string{"id": id})
+		}
+		_ = json.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodGet && path == "/organizations/"+raceTestOrganization+"/policies":
+		if a.attempts%10 == 0 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `[{"id":"pol-1"}]`)
+	case r.Method == http.MethodPost && path == routes:
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id, ok := a.idempotencyKeys[key]
+		if !ok {
+			id = fmt.Sprintf("rt-%d", len(a.routes)+1)
+			a.routes = append(a.routes, id)
+			a.idempotencyKeys[key] = id
+			// Fail some creates after processing them, so that they are retried with the same key
+			if len(a.routes)%7 == 0 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": id})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// raceTestJWT returns an unsigned JWT expiring at expiry, which is unique for each n.
+func raceTestJWT(n int, expiry time.Time) string {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	return encode(map[string]string{"alg": "none"}) + "." + encode(map[string]int64{"exp": expiry.Unix(), "jti": int64(n)}) + ".sig"
+}
+
+// TestTransportChainConcurrency sends many concurrent requests through the transports of the provider,
+// while the token expires, lists are cached and invalidated, and requests are retried and rate limited.
+// It is meant to be run with the race detector.
+func TestTransportChainConcurrency(t *testing.T) {
+	resetAPIMetrics(t)
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	api := &raceTestAPI{tokens: map[string]time.Time{}, idempotencyKeys: map[string]string{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	base, err := newAPIURLTransport(http.DefaultTransport, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := newAPITransport(base, 5*time.Second)
+	client := &http.Client{Transport: tokens}
+
+	ctx := context.Background()
+	token, err := getToken(ctx, client, "api-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens.start(token, func(ctx context.Context) (string, error) {
+		return getToken(ctx, client, "api-token")
+	})
+
+	const (
+		workers    = 16
+		iterations = 30
+	)
+	routesURL := fmt.Sprintf("%s/organizations/%s/routes", apiBaseURL, raceTestOrganization)
+	policiesURL := fmt.Sprintf("%s/organizations/%s/policies", apiBaseURL, raceTestOrganization)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				// Resources keep sending the token obtained in Configure, which the chain renews
+				switch (worker + i) % 3 {
+				case 0:
+					var routes []map[string]interface{}
+					if err := doAPIRequest(ctx, client, token, http.MethodGet, routesURL, nil, http.StatusOK, &routes); err != nil {
+						t.Errorf("worker %d: listing routes: %s", worker, err)
+					}
+				case 1:
+					var policies []map[string]interface{}
+					if err := doAPIRequest(ctx, client, token, http.MethodGet, policiesURL, nil, http.StatusOK, &policies); err != nil {
+						t.Errorf("worker %d: listing policies: %s", worker, err)
+					}
+				case 2:
+					var route map[string]interface{}
+					if err := doAPIRequest(withIdempotencyKey(ctx), client, token, http.MethodPost, routesURL, map[string]string{"name": "app"}, http.StatusCreated, &route); err != nil {
+						t.Errorf("worker %d: creating route: %s", worker, err)
+					}
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}(worker)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	api.mu.Lock()
+	created, exchanges := len(api.routes), api.exchanges
+	api.mu.Unlock()
+
+	// Each create made a single route, even when it was retried
+	if want := workers * iterations / 3; created != want {
+		t.Errorf("expected %d routes to be created, got %d", want, created)
+	}
+	if exchanges < 2 {
+		t.Errorf("expected the token to be renewed while requests were in flight, got %d exchanges", exchanges)
+	}
+
+	// Lists read after the creates are not served from a stale cache
+	for i := 0; i < 2; i++ {
+		var routes []map[string]interface{}
+		if err := doAPIRequest(ctx, client, token, http.MethodGet, routesURL, nil, http.StatusOK, &routes); err != nil {
+			t.Fatal(err)
+		}
+		if len(routes) != created {
+			t.Errorf("expected the list of %d routes, got %d routes", created, len(routes))
+		}
+	}
+	api.mu.Lock()
+	attempts := api.attempts
+	api.mu.Unlock()
+
+	// Every attempt received by the API is counted once, either as a request or as a retry
+	apiMetrics.mu.Lock()
+	var requests, retries int
+	for _, metrics := range apiMetrics.endpoints {
+		requests += metrics.requests
+		retries += metrics.retries
+	}
+	apiMetrics.mu.Unlock()
+	if requests+retries != attempts {
+		t.Errorf("expected %d requests and retries to be counted, got %d requests and %d retries", attempts, requests, retries)
+	}
+	if retries == 0 {
+		t.Error("expected transient errors to be retried")
+	}
+}
+
+// TestListCacheConcurrentInvalidation reads lists while other requests invalidate them, and checks
+// that a list read once all writes completed reflects every write.
+func TestListCacheConcurrentInvalidation(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	var mu sync.Mutex
+	var count, reads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			// Writes take a while to be processed, so that lists are read while they are in flight
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			count++
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		mu.Lock()
+		n := count
+		reads++
+		mu.Unlock()
+		fmt.Fprint(w, n)
+	}))
+	t.Cleanup(server.Close)
+
+	cache := newListCacheTransport(http.DefaultTransport)
+	client := &http.Client{Transport: cache}
+	list := func() int {
+		resp, err := client.Get(server.URL + "/routes")
+		if err != nil {
+			t.Error(err)
+			return -1
+		}
+		defer resp.Body.Close()
+		var n int
+		if err := json.NewDecoder(resp.Body).Decode(&n); err != nil {
+			t.Error(err)
+		}
+		return n
+	}
+
+	const writes = 50
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for reader := 0; reader < 8; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					list()
+				}
+			}
+		}()
+	}
+	var writers sync.WaitGroup
+	for writer := 0; writer < writes; writer++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			resp, err := client.Post(server.URL+"/routes", "application/json", strings.NewReader("{}"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	writers.Wait()
+
+	close(done)
+	wg.Wait()
+
+	if n := list(); n != writes {
+		t.Errorf("expected the list read after all writes to count %d writes, got %d", writes, n)
+	}
+
+	// The list is cached again once the writes completed
+	mu.Lock()
+	before := reads
+	mu.Unlock()
+	if n := list(); n != writes {
+		t.Errorf("expected the cached list to count %d writes, got %d", writes, n)
+	}
+	mu.Lock()
+	after := reads
+	mu.Unlock()
+	if after != before {
+		t.Errorf("expected the list to be served from the cache, got %d reads by the server", after-before)
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// warnUnappliedChangesets returns a warning for each cluster selected by match that has changesets
+// pending while auto_apply_changesets is disabled. Terraform reports such applies as successful,
+// even though the changes are not live until the changesets are applied. Errors are only logged,
+// as the check is informational and must not fail an otherwise successful apply.
+func warnUnappliedChangesets(ctx context.Context, client *http.Client, token, organizationID string, match func(Cluster) bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters", apiBaseURL, organizationID)
+	var clusters []Cluster
+	if err := doAPIRequest(ctx, client, token, "GET", url, nil, http.StatusOK, &clusters); err != nil {
+		log.Printf("[DEBUG] Unable to list clusters to check for unapplied changesets: %s", err)
+		return diags
+	}
+
+	changesets := &ChangesetResource{client: client, token: token, organizationID: organizationID}
+	settings := &ClusterSettingsResource{client: client, token: token, organizationID: organizationID}
+
+	for _, cluster := range clusters {
+		if !match(cluster) {
+			continue
+		}
+
+		pending, err := changesets.listPendingChangesets(ctx, cluster.ID)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to list pending changesets of cluster %s: %s", cluster.ID, err)
+			continue
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		clusterSettings, err := settings.getClusterSettings(ctx, cluster.ID)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to read settings of cluster %s: %s", cluster.ID, err)
+			continue
+		}
+		if clusterSettings.AutoApplyChangesets {
+			continue
+		}
+
+		var summary strings.Builder
+		for _, changeset := range pending {
+			fmt.Fprintf(&summary, "\n  - %s", changeset.Description)
+		}
+
+		diags.AddWarning(
+			"Changes Not Applied",
+			fmt.Sprintf("Cluster %q has %d pending changesets, which are not live because auto_apply_changesets is disabled:%s\n\n"+
+				"Apply them in the Pomerium Zero console, or add a pomeriumzero_changeset resource to apply them as part of the Terraform run.",
+				cluster.Name, len(pending), summary.String()),
+		)
+	}
+
+	return diags
+}
+
+// inNamespace returns a match function for warnUnappliedChangesets selecting the clusters of a namespace.
+func inNamespace(namespaceID string) func(Cluster) bool {
+	return func(cluster Cluster) bool {
+		return cluster.NamespaceID == namespaceID
+	}
+}
+
+// isCluster returns a match function for warnUnappliedChangesets selecting a single cluster.
+func isCluster(clusterID string) func(Cluster) bool {
+	return func(cluster Cluster) bool {
+		return cluster.ID == clusterID
+	}
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+)
+
+// errUnknownCredentials is returned for API requests made before the api_token of the provider is known.
+var errUnknownCredentials = errors.New("the api_token of the provider is not known until apply, so Pomerium Zero cannot be " +
+	"queried during this plan. Create the token in an earlier run, or use Terraform 1.9 or later with deferred actions enabled")
+
+// unknownCredentialsTransport rejects all requests. It is used while the api_token of the provider is unknown,
+// so that planning objects that need no API calls succeeds, while those that do fail with a clear error.
+type unknownCredentialsTransport struct{}
+
+// RoundTrip rejects the request.
+func (unknownCredentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, errUnknownCredentials
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsageDataSource{}
+
+// NewUsageDataSource creates a new UsageDataSource.
+func NewUsageDataSource() datasource.DataSource {
+	return &UsageDataSource{}
+}
+
+// UsageDataSource defines the data source implementation.
+type UsageDataSource struct {
+	client         *http.Client
+	token          string
+	organizationID string
+}
+
+// UsageDataSourceModel describes the data source data model.
+type UsageDataSourceModel struct {
+	Clusters QuotaDataModel `tfsdk:"clusters"`
+	Routes   QuotaDataModel `tfsdk:"routes"`
+	Seats    QuotaDataModel `tfsdk:"seats"`
+}
+
+// QuotaDataModel describes the usage of a single quota.
+type QuotaDataModel struct {
+	Used      types.Int64 `tfsdk:"used"`
+	Limit     types.Int64 `tfsdk:"limit"`
+	Remaining types.Int64 `tfsdk:"remaining"`
+}
+
+// Metadata sets the data source type name for the UsageDataSource.
+func (d *UsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage"
+}
+
+// Schema defines the structure and attributes of the UsageDataSource.
+func (d *UsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exposes how much of the quotas of the organization's plan is used, " +
+			"e.g. to fail with a readable error before a plan limit is hit halfway through an apply.",
+		Attributes: map[string]schema.Attribute{
+			"clusters": quotaSchemaAttribute("clusters"),
+			"routes":   quotaSchemaAttribute("routes"),
+			"seats":    quotaSchemaAttribute("seats, i.e. members"),
+		},
+	}
+}
+
+// quotaSchemaAttribute returns the schema of the usage of a single quota.
+func quotaSchemaAttribute(name string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("The usage of the %s quota.", name),
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"used": schema.Int64Attribute{
+				MarkdownDescription: "How much of the quota is used.",
+				Computed:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The limit of the quota. Not set when the quota is unlimited.",
+				Computed:            true,
+			},
+			"remaining": schema.Int64Attribute{
+				MarkdownDescription: "How much of the quota is left. Not set when the quota is unlimited.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares a Pomerium Zero API client for the UsageDataSource.
+func (d *UsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	provider, ok := req.ProviderData.(*pomeriumZeroProvider)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *pomeriumZeroProvider, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client, d.token, d.organizationID = provider.apiCredentials()
+}
+
+// Read retrieves the usage of the organization.
+func (d *UsageDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	url := fmt.Sprintf("%s/organizations/%s/usage", apiBaseURL, d.organizationID)
+
+	var usage Usage
+	if err := doAPIRequest(ctx, d.client, d.token, "GET", url, nil, http.StatusOK, &usage); err != nil {
+		resp.Diagnostics.AddError("Error fetching usage", err.Error())
+		return
+	}
+
+	data := UsageDataSourceModel{
+		Clusters: quotaDataModel(usage.Clusters),
+		Routes:   quotaDataModel(usage.Routes),
+		Seats:    quotaDataModel(usage.Seats),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// quotaDataModel maps the usage of a quota returned by the API.
+func quotaDataModel(quota Quota) QuotaDataModel {
+	model := QuotaDataModel{
+		Used:      types.Int64Value(quota.Used),
+		Limit:     types.Int64Null(),
+		Remaining: types.Int64Null(),
+	}
+	if quota.Limit != nil {
+		model.Limit = types.Int64Value(*quota.Limit)
+		model.Remaining = types.Int64Value(max(*quota.Limit-quota.Used, 0))
+	}
+	return model
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidatePPLFunction{}
+
+// NewValidatePPLFunction creates a new ValidatePPLFunction.
+func NewValidatePPLFunction() function.Function {
+	return &ValidatePPLFunction{}
+}
+
+// ValidatePPLFunction validates and normalizes a JSON PPL document.
+type ValidatePPLFunction struct{}
+
+// Metadata sets the name of the ValidatePPLFunction.
+func (f *ValidatePPLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_ppl"
+}
+
+// Definition defines the parameters and return type of the ValidatePPLFunction.
+func (f *ValidatePPLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validates a JSON PPL document",
+		MarkdownDescription: "Validates the structure of a Pomerium Policy Language (PPL) document written in JSON and returns it " +
+			"in canonical form, with sorted object keys and without whitespace. The document must be a rule or a list of rules, " +
+			"where each rule maps `allow` or `deny` to the logical operators `and`, `or`, `not` and `nor`, which in turn hold " +
+			"lists of known criteria or nested logical operators. An error is raised for invalid documents, so the function can " +
+			"be used in preconditions before the document reaches `pomeriumzero_policy`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ppl",
+				MarkdownDescription: "The JSON PPL document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the PPL document.
+func (f *ValidatePPLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	ppl, err := parsePPL(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid PPL: %s", err))
+		return
+	}
+
+	result, err := encodePPL(ppl)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
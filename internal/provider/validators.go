@@ -0,0 +1,657 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringOneOfValidator{}
+
+// stringOneOfValidator validates that a string attribute is one of a fixed set of values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator which ensures that a configured string is one of the given values.
+// Null and unknown values are not validated.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", v.quotedValues())
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+	)
+}
+
+// quotedValues returns the allowed values as a comma separated list of quoted strings.
+func (v stringOneOfValidator) quotedValues() string {
+	quoted := make([]string, 0, len(v.values))
+	for _, value := range v.values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsDurationValidator{}
+
+// stringIsDurationValidator validates that a string attribute is a Go-style duration, e.g. "30s" or "1h30m".
+type stringIsDurationValidator struct{}
+
+// stringIsDuration returns a validator which ensures that a configured string parses as a duration.
+// Null and unknown values are not validated.
+func stringIsDuration() validator.String {
+	return stringIsDurationValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsDurationValidator) Description(_ context.Context) string {
+	return `value must be a duration such as "30s", "5m" or "1h30m"`
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsDurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringMatchesValidator{}
+
+// stringMatchesValidator validates that a string attribute matches a regular expression.
+type stringMatchesValidator struct {
+	pattern *regexp.Regexp
+	message string
+}
+
+// stringMatches returns a validator which ensures that a configured string matches the pattern.
+// The message describes the expected format to the user. Null and unknown values are not validated.
+func stringMatches(pattern *regexp.Regexp, message string) validator.String {
+	return stringMatchesValidator{pattern: pattern, message: message}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringMatchesValidator) Description(_ context.Context) string {
+	return v.message
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringMatchesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringMatchesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !v.pattern.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsMarkdownValidator{}
+
+var (
+	// markdownHTMLPattern matches raw HTML tags, which are not rendered on Pomerium pages
+	markdownHTMLPattern = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	// markdownLinkPattern matches inline links and images, capturing the link target
+	markdownLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(([^)\s]*)[^)]*\)`)
+)
+
+// stringIsMarkdownValidator validates that a string attribute is markdown that can be rendered
+// on the pages served by Pomerium: no raw HTML, and only http, https and mailto links.
+type stringIsMarkdownValidator struct{}
+
+// stringIsMarkdown returns a validator which ensures that a configured string is renderable markdown.
+// Null and unknown values are not validated.
+func stringIsMarkdown() validator.String {
+	return stringIsMarkdownValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsMarkdownValidator) Description(_ context.Context) string {
+	return "value must be markdown without raw HTML, with links using the http, https or mailto scheme"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsMarkdownValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsMarkdownValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if tag := markdownHTMLPattern.FindString(value); tag != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Markdown",
+			fmt.Sprintf("Attribute %s %s, found HTML tag: %q", req.Path, v.Description(ctx), tag),
+		)
+		return
+	}
+
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(value, -1) {
+		target := strings.ToLower(match[1])
+		if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") && !strings.HasPrefix(target, "mailto:") {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Markdown",
+				fmt.Sprintf("Attribute %s %s, found link: %q", req.Path, v.Description(ctx), match[0]),
+			)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.Set = setElementsOneOfValidator{}
+
+// setElementsOneOfValidator validates that every element of a set of strings is one of a fixed set of values.
+type setElementsOneOfValidator struct {
+	oneOf stringOneOfValidator
+}
+
+// setElementsOneOf returns a validator which ensures that every configured element of a set of strings
+// is one of the given values. Null and unknown sets and elements are not validated.
+func setElementsOneOf(values ...string) validator.Set {
+	return setElementsOneOfValidator{oneOf: stringOneOfValidator{values: values}}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v setElementsOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("elements must be one of: %s", v.oneOf.quotedValues())
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v setElementsOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateSet performs the validation.
+func (v setElementsOneOfValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		elementReq := validator.StringRequest{
+			Path:           req.Path.AtSetValue(value),
+			PathExpression: req.PathExpression.AtSetValue(value),
+			ConfigValue:    value,
+			Config:         req.Config,
+		}
+		elementResp := &validator.StringResponse{}
+		v.oneOf.ValidateString(ctx, elementReq, elementResp)
+		resp.Diagnostics.Append(elementResp.Diagnostics...)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsRFC3339Validator{}
+
+// stringIsRFC3339Validator validates that a string attribute is an RFC 3339 timestamp, e.g. "2025-01-31T00:00:00Z".
+type stringIsRFC3339Validator struct{}
+
+// stringIsRFC3339 returns a validator which ensures that a configured string parses as an RFC 3339 timestamp.
+// Null and unknown values are not validated.
+func stringIsRFC3339() validator.String {
+	return stringIsRFC3339Validator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsRFC3339Validator) Description(_ context.Context) string {
+	return `value must be an RFC 3339 timestamp such as "2025-01-31T00:00:00Z"`
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsRFC3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsRFC3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsPEMCertificatesValidator{}
+
+// stringIsPEMCertificatesValidator validates that a string attribute is a bundle of PEM encoded X.509 certificates.
+type stringIsPEMCertificatesValidator struct{}
+
+// stringIsPEMCertificates returns a validator which ensures that a configured string holds one or more PEM
+// encoded X.509 certificates, and nothing else. Null and unknown values are not validated.
+func stringIsPEMCertificates() validator.String {
+	return stringIsPEMCertificatesValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsPEMCertificatesValidator) Description(_ context.Context) string {
+	return "value must be one or more PEM encoded X.509 certificates"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsPEMCertificatesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsPEMCertificatesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	rest := []byte(req.ConfigValue.ValueString())
+	certificates := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Certificate",
+				fmt.Sprintf("Attribute %s %s, got a PEM block of type %q", req.Path, v.Description(ctx), block.Type),
+			)
+			return
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid Certificate",
+				fmt.Sprintf("Attribute %s %s, certificate %d does not parse: %s", req.Path, v.Description(ctx), certificates+1, err),
+			)
+			return
+		}
+		certificates++
+	}
+
+	if certificates == 0 || strings.TrimSpace(string(rest)) != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Certificate",
+			fmt.Sprintf("Attribute %s %s", req.Path, v.Description(ctx)),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsURLValidator{}
+
+// stringIsURLValidator validates that a string attribute is an absolute URL with one of the given schemes.
+type stringIsURLValidator struct {
+	schemes []string
+}
+
+// stringIsURL returns a validator which ensures that a configured string is an absolute URL with a host
+// and one of the given schemes. Null and unknown values are not validated.
+func stringIsURL(schemes ...string) validator.String {
+	return stringIsURLValidator{schemes: schemes}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsURLValidator) Description(_ context.Context) string {
+	schemes := make([]string, 0, len(v.schemes))
+	for _, scheme := range v.schemes {
+		schemes = append(schemes, scheme+"://")
+	}
+	return fmt.Sprintf("value must be a URL starting with %s", strings.Join(schemes, ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || !containsString(v.schemes, strings.ToLower(u.Scheme)) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// idPattern matches the identifiers of Pomerium Zero objects, e.g. "bZPhcRUBcFwVlLCEPsSHMTxEqLR".
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// stringIsID returns a validator which ensures that a configured string is the identifier of a Pomerium Zero object.
+// Null and unknown values are not validated.
+func stringIsID() validator.String {
+	return stringMatches(idPattern, `value must be a Pomerium Zero ID such as "bZPhcRUBcFwVlLCEPsSHMTxEqLR"`)
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.String = stringIsPPLValidator{}
+
+// stringIsPPLValidator validates that a string attribute is a JSON PPL document.
+type stringIsPPLValidator struct{}
+
+// stringIsPPL returns a validator which ensures that a configured string is a structurally valid JSON PPL document.
+// Null and unknown values are not validated.
+func stringIsPPL() validator.String {
+	return stringIsPPLValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v stringIsPPLValidator) Description(_ context.Context) string {
+	return "value must be a JSON Pomerium Policy Language (PPL) document"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v stringIsPPLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v stringIsPPLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parsePPL(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid PPL",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.List = listSizeAtLeastValidator{}
+
+// listSizeAtLeastValidator validates that a list attribute has a minimum number of elements.
+type listSizeAtLeastValidator struct {
+	minimum int
+}
+
+// listSizeAtLeast returns a validator which ensures that a configured list has at least minimum elements.
+// Null and unknown lists are not validated.
+func listSizeAtLeast(minimum int) validator.List {
+	return listSizeAtLeastValidator{minimum: minimum}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v listSizeAtLeastValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("list must contain at least %d elements", v.minimum)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v listSizeAtLeastValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList performs the validation.
+func (v listSizeAtLeastValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if len(req.ConfigValue.Elements()) < v.minimum {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", req.Path, v.Description(ctx), len(req.ConfigValue.Elements())),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.List = listElementsValidator{}
+
+// listElementsValidator applies string validators to every element of a list of strings.
+type listElementsValidator struct {
+	validators []validator.String
+}
+
+// listElements returns a validator which applies the given string validators to every configured element
+// of a list of strings. Null and unknown lists and elements are not validated.
+func listElements(validators ...validator.String) validator.List {
+	return listElementsValidator{validators: validators}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v listElementsValidator) Description(ctx context.Context) string {
+	descriptions := make([]string, 0, len(v.validators))
+	for _, elementValidator := range v.validators {
+		descriptions = append(descriptions, elementValidator.Description(ctx))
+	}
+	return fmt.Sprintf("for every element: %s", strings.Join(descriptions, ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v listElementsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList performs the validation.
+func (v listElementsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		elementReq := validator.StringRequest{
+			Path:           req.Path.AtListIndex(i),
+			PathExpression: req.PathExpression.AtListIndex(i),
+			ConfigValue:    value,
+			Config:         req.Config,
+		}
+		for _, elementValidator := range v.validators {
+			elementResp := &validator.StringResponse{}
+			elementValidator.ValidateString(ctx, elementReq, elementResp)
+			resp.Diagnostics.Append(elementResp.Diagnostics...)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ validator.List = listSameURLSchemeValidator{}
+
+// listSameURLSchemeValidator validates that all URLs of a list of strings have the same scheme.
+type listSameURLSchemeValidator struct{}
+
+// listSameURLScheme returns a validator which ensures that all configured URLs of a list of strings have
+// the same scheme, compared case-insensitively. Null and unknown lists and elements, and elements that
+// are not URLs, are not validated.
+func listSameURLScheme() validator.List {
+	return listSameURLSchemeValidator{}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v listSameURLSchemeValidator) Description(_ context.Context) string {
+	return "all URLs must have the same scheme"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v listSameURLSchemeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateList performs the validation.
+func (v listSameURLSchemeValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var first string
+	for i, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		u, err := url.Parse(value.ValueString())
+		if err != nil || u.Scheme == "" {
+			continue
+		}
+
+		scheme := strings.ToLower(u.Scheme)
+		if first == "" {
+			first = scheme
+			continue
+		}
+		if scheme != first {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Inconsistent URL Schemes",
+				fmt.Sprintf("Attribute %s %s: the first URL uses %s://, but this one uses %s://, got: %q",
+					req.Path, v.Description(ctx), first, scheme, value.ValueString()),
+			)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.ConfigValidator = requiredTogetherValidator{}
+
+// requiredTogetherValidator validates that a group of top-level attributes is either entirely set or not set at all.
+type requiredTogetherValidator struct {
+	names []string
+}
+
+// requiredTogether returns a resource validator which ensures that when any of the named attributes is
+// configured, all of them are. Configurations with unknown values among the attributes are not validated.
+func requiredTogether(names ...string) resource.ConfigValidator {
+	return requiredTogetherValidator{names: names}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v requiredTogetherValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("attributes %s must be configured together", strings.Join(v.names, ", "))
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v requiredTogetherValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource performs the validation.
+func (v requiredTogetherValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var set, missing int
+	var missingNames []string
+	for _, name := range v.names {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch {
+		case value.IsUnknown():
+			return
+		case value.IsNull():
+			missing++
+			missingNames = append(missingNames, name)
+		default:
+			set++
+		}
+	}
+
+	if set == 0 || missing == 0 {
+		return
+	}
+
+	for _, name := range missingNames {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Missing Attribute Configuration",
+			fmt.Sprintf("Attribute %s must be configured, as %s.", name, v.Description(ctx)),
+		)
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	resource_schema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// deploymentTimeout bounds how long to wait for a change to be deployed, unless the operation
+	// has a shorter deadline.
+	deploymentTimeout = 10 * time.Minute
+	// deploymentPollInterval is the delay between two checks of the pending changesets.
+	deploymentPollInterval = 5 * time.Second
+)
+
+// waitForDeploymentAttribute returns the schema of the wait_for_deployment attribute. The
+// attribute is only stored in the Terraform state.
+func waitForDeploymentAttribute(resourceName string) resource_schema.BoolAttribute {
+	return resource_schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("If set to `true`, changing the %s waits until the change is deployed, "+
+			"i.e. until the affected clusters have no pending changesets left, for up to %d minutes. "+
+			"Clusters with `auto_apply_changesets` disabled are not waited for, as their changes are only deployed once applied.",
+			resourceName, int(deploymentTimeout/time.Minute)),
+	}
+}
+
+// awaitDeployment follows up on a change of the clusters selected by match: it waits for the change
+// to be deployed when wait is true, and warns when the change is not live yet because changesets are
+// pending on a cluster that does not apply them automatically.
+func awaitDeployment(ctx context.Context, client *http.Client, token, organizationID string, wait types.Bool, match func(Cluster) bool) diag.Diagnostics {
+	if wait.ValueBool() {
+		if err := waitForDeployment(ctx, client, token, organizationID, match); err != nil {
+			return errorDiagnostics("Error Waiting for Deployment", err.Error(), err)
+		}
+	}
+
+	return warnUnappliedChangesets(ctx, client, token, organizationID, match)
+}
+
+// waitForDeployment polls the clusters selected by match until none of them has pending changesets.
+// Clusters that do not apply changesets automatically are skipped, as waiting for them would only
+// time out.
+func waitForDeployment(ctx context.Context, client *http.Client, token, organizationID string, match func(Cluster) bool) error {
+	ctx, cancel := context.WithTimeout(ctx, deploymentTimeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/organizations/%s/clusters", apiBaseURL, organizationID)
+	var clusters []Cluster
+	if err := doAPIRequest(ctx, client, token, "GET", url, nil, http.StatusOK, &clusters); err != nil {
+		return fmt.Errorf("error listing clusters: %w", err)
+	}
+
+	changesets := &ChangesetResource{client: client, token: token, organizationID: organizationID}
+	settings := &ClusterSettingsResource{client: client, token: token, organizationID: organizationID}
+
+	for _, cluster := range clusters {
+		if !match(cluster) {
+			continue
+		}
+
+		clusterSettings, err := settings.getClusterSettings(ctx, cluster.ID)
+		if err != nil {
+			return fmt.Errorf("error reading settings of cluster %q: %w", cluster.Name, err)
+		}
+		if !clusterSettings.AutoApplyChangesets {
+			log.Printf("[INFO] Not waiting for cluster %s, which does not apply changesets automatically", cluster.ID)
+			continue
+		}
+
+		for {
+			pending, err := changesets.listPendingChangesets(ctx, cluster.ID)
+			if err != nil {
+				return fmt.Errorf("error listing pending changesets of cluster %q: %w", cluster.Name, err)
+			}
+			if len(pending) == 0 {
+				break
+			}
+
+			log.Printf("[DEBUG] Waiting for %d pending changesets of cluster %s to be deployed", len(pending), cluster.ID)
+			select {
+			case <-time.After(deploymentPollInterval):
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("timed out waiting for %d pending changesets of cluster %q to be deployed", len(pending), cluster.Name)
+				}
+				return ctx.Err()
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,615 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// yamlSubsetDescription documents the subset of YAML that decodeYAML supports, for the attributes it decodes.
+const yamlSubsetDescription = "YAML is read by a built-in parser supporting the subset used by Pomerium configuration files: " +
+	"a single document, optionally starting with `---`; block mappings and sequences indented with spaces; " +
+	"flow mappings and sequences (`{...}` and `[...]`), which may span lines; plain scalars, which may be folded over more indented lines; " +
+	"single and double quoted scalars on a single line; literal (`|`) and folded (`>`) block scalars with chomping and indentation indicators; " +
+	"and `#` comments. Scalars are resolved following the YAML 1.2 core schema. " +
+	"Anchors, aliases, tags, duplicate keys, multiple documents and tabs for indentation are rejected."
+
+// decodeYAML decodes a single YAML document into the same types encoding/json produces when decoding
+// into an interface{}: map[string]interface{}, []interface{}, string, bool, float64 and nil. Integers are
+// decoded as int64 so large values survive a round trip.
+//
+// Only the subset of YAML found in Pomerium configuration files is supported: block and flow collections,
+// plain and quoted scalars, block scalars and comments. Anchors, aliases, tags and multiple documents
+// are rejected.
+func decodeYAML(data []byte) (interface{}, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if leading := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]; strings.Contains(leading, "\t") && strings.TrimSpace(raw) != "" {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		text := strings.TrimSpace(stripYAMLComment(raw))
+		p.lines = append(p.lines, yamlLine{
+			number: i + 1,
+			raw:    raw,
+			indent: len(raw) - len(strings.TrimLeft(raw, " ")),
+			text:   text,
+		})
+	}
+
+	// Skip the optional document start marker
+	p.skipBlank()
+	if !p.done() && p.current().text == "---" {
+		p.pos++
+	}
+
+	value, err := p.parseNode(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipBlank()
+	if !p.done() {
+		line := p.current()
+		if line.text == "---" {
+			return nil, fmt.Errorf("line %d: multiple documents are not supported", line.number)
+		}
+		if line.text != "..." {
+			return nil, fmt.Errorf("line %d: unexpected content %q", line.number, line.text)
+		}
+	}
+
+	return value, nil
+}
+
+// yamlLine is a single line of a YAML document.
+type yamlLine struct {
+	number int
+	raw    string
+	indent int
+	text   string
+}
+
+// yamlParser is a recursive descent parser for block style YAML.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) done() bool {
+	return p.pos >= len(p.lines)
+}
+
+func (p *yamlParser) current() *yamlLine {
+	return &p.lines[p.pos]
+}
+
+// skipBlank advances past empty and comment-only lines.
+func (p *yamlParser) skipBlank() {
+	for !p.done() && p.current().text == "" {
+		p.pos++
+	}
+}
+
+// parseNode parses the node starting at the current line, if it is indented at least minIndent.
+func (p *yamlParser) parseNode(minIndent int) (interface{}, error) {
+	p.skipBlank()
+	if p.done() {
+		return nil, nil
+	}
+
+	line := p.current()
+	if line.indent < minIndent || line.text == "---" || line.text == "..." {
+		return nil, nil
+	}
+
+	switch {
+	case isYAMLSequenceItem(line.text):
+		return p.parseSequence(line.indent)
+	case yamlMappingKeyEnd(line.text) >= 0:
+		return p.parseMapping(line.indent)
+	default:
+		return p.parseInlineValue(line.indent - 1)
+	}
+}
+
+// parseSequence parses a block sequence whose items are indented exactly indent.
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+
+	for {
+		p.skipBlank()
+		if p.done() {
+			break
+		}
+		line := p.current()
+		if line.indent != indent || !isYAMLSequenceItem(line.text) {
+			if line.indent > indent {
+				return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+			}
+			break
+		}
+
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if rest == "" {
+			p.pos++
+			item, err := p.parseNode(indent + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			continue
+		}
+
+		// Parse the content after the dash as if it started a line of its own,
+		// so that "- key: value" opens a mapping indented at the key
+		line.indent += len(line.text) - len(rest)
+		line.text = rest
+		item, err := p.parseNode(line.indent)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// parseMapping parses a block mapping whose keys are indented exactly indent.
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	mapping := map[string]interface{}{}
+
+	for {
+		p.skipBlank()
+		if p.done() {
+			break
+		}
+		line := p.current()
+		if line.indent != indent || line.text == "---" || line.text == "..." {
+			if line.indent > indent {
+				return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+			}
+			break
+		}
+
+		end := yamlMappingKeyEnd(line.text)
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: expected a mapping key, got %q", line.number, line.text)
+		}
+
+		key, err := parseYAMLScalarString(strings.TrimSpace(line.text[:end]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		if _, exists := mapping[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+
+		rest := strings.TrimSpace(line.text[end+1:])
+		var value interface{}
+		if rest == "" {
+			p.pos++
+			p.skipBlank()
+			switch {
+			case p.done():
+			case p.current().indent > indent:
+				value, err = p.parseNode(indent + 1)
+			case p.current().indent == indent && isYAMLSequenceItem(p.current().text):
+				// Sequences may be indented at the same level as their parent key
+				value, err = p.parseSequence(indent)
+			}
+		} else {
+			line.text = rest
+			value, err = p.parseInlineValue(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		mapping[key] = value
+	}
+
+	return mapping, nil
+}
+
+// parseInlineValue parses the value held in the text of the current line. Continuation lines
+// must be indented more than parentIndent.
+func (p *yamlParser) parseInlineValue(parentIndent int) (interface{}, error) {
+	line := p.current()
+	text := line.text
+
+	switch {
+	case strings.HasPrefix(text, "&"), strings.HasPrefix(text, "*"):
+		return nil, fmt.Errorf("line %d: anchors and aliases are not supported", line.number)
+	case strings.HasPrefix(text, "!"):
+		return nil, fmt.Errorf("line %d: tags are not supported", line.number)
+	case strings.HasPrefix(text, "|"), strings.HasPrefix(text, ">"):
+		return p.parseBlockScalar(parentIndent)
+	case strings.HasPrefix(text, "["), strings.HasPrefix(text, "{"):
+		// Flow collections may span multiple lines
+		startLine := line.number
+		for p.pos++; !yamlFlowComplete(text); p.pos++ {
+			if p.done() {
+				return nil, fmt.Errorf("line %d: unterminated flow collection", startLine)
+			}
+			text += " " + p.current().text
+		}
+		value, rest, err := parseYAMLFlow(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", startLine, err)
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("line %d: unexpected content %q after flow collection", startLine, rest)
+		}
+		return value, nil
+	case strings.HasPrefix(text, `"`), strings.HasPrefix(text, `'`):
+		p.pos++
+		value, err := parseYAMLScalarString(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		return value, nil
+	}
+
+	// Plain scalars may be folded over multiple more indented lines
+	p.pos++
+	for !p.done() {
+		next := p.current()
+		if next.text == "" {
+			p.pos++
+			continue
+		}
+		if next.indent <= parentIndent || isYAMLSequenceItem(next.text) || yamlMappingKeyEnd(next.text) >= 0 {
+			break
+		}
+		text += " " + next.text
+		p.pos++
+	}
+
+	if err := checkYAMLPlainScalar(text); err != nil {
+		return nil, fmt.Errorf("line %d: %w", line.number, err)
+	}
+	return resolveYAMLPlainScalar(text), nil
+}
+
+// checkYAMLPlainScalar rejects plain scalars of the block context that a YAML parser would not read as
+// a single string: those holding a mapping value indicator, as in "a: b: c", and those starting with
+// a reserved indicator.
+func checkYAMLPlainScalar(text string) error {
+	if strings.Contains(text, ": ") || strings.HasSuffix(text, ":") {
+		return fmt.Errorf("mapping values are not allowed in the plain scalar %q, quote it instead", text)
+	}
+	if strings.HasPrefix(text, "@") || strings.HasPrefix(text, "`") {
+		return fmt.Errorf("plain scalars cannot start with %q, quote %q instead", text[:1], text)
+	}
+	return nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar.
+func (p *yamlParser) parseBlockScalar(parentIndent int) (interface{}, error) {
+	header := p.current()
+	style := header.text[0]
+	chomping := byte(0)
+	contentIndent := 0
+	for _, c := range header.text[1:] {
+		switch {
+		case c == '-' || c == '+':
+			chomping = byte(c)
+		case c >= '1' && c <= '9':
+			contentIndent = max(parentIndent, 0) + int(c-'0')
+		default:
+			return nil, fmt.Errorf("line %d: invalid block scalar header %q", header.number, header.text)
+		}
+	}
+	p.pos++
+
+	var lines []string
+	for ; !p.done(); p.pos++ {
+		line := p.current()
+		if strings.TrimSpace(line.raw) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if contentIndent == 0 {
+			contentIndent = line.indent
+		}
+		if line.indent < contentIndent || line.indent <= parentIndent {
+			break
+		}
+		lines = append(lines, line.raw[contentIndent:])
+	}
+
+	// Trailing blank lines are only kept with the keep chomping indicator
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+
+	var value string
+	if style == '|' {
+		value = strings.Join(lines, "\n")
+	} else {
+		// Line breaks between lines of text fold into a space, or are dropped when followed by empty
+		// lines, which each keep their line break. Breaks around more indented lines are kept.
+		var b strings.Builder
+		previous, empty := -1, 0
+		for i, line := range lines {
+			if line == "" {
+				empty++
+				continue
+			}
+			switch {
+			case previous < 0:
+				b.WriteString(strings.Repeat("\n", empty))
+			case strings.HasPrefix(line, " ") || strings.HasPrefix(lines[previous], " "):
+				b.WriteString(strings.Repeat("\n", empty+1))
+			case empty > 0:
+				b.WriteString(strings.Repeat("\n", empty))
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(line)
+			previous, empty = i, 0
+		}
+		value = b.String()
+	}
+
+	switch {
+	case len(lines) == 0:
+	case chomping == '-':
+	case chomping == '+':
+		value += strings.Repeat("\n", trailing+1)
+	default:
+		value += "\n"
+	}
+
+	return value, nil
+}
+
+// isYAMLSequenceItem reports whether the text starts a block sequence item.
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlMappingKeyEnd returns the index of the colon ending the mapping key at the start of text,
+// or -1 if text does not start with a mapping key.
+func yamlMappingKeyEnd(text string) int {
+	if text == "" || strings.ContainsRune("[{|>&*!", rune(text[0])) {
+		return -1
+	}
+
+	start := 0
+	if text[0] == '"' || text[0] == '\'' {
+		end := yamlQuotedEnd(text)
+		if end < 0 {
+			return -1
+		}
+		start = end + 1
+	}
+
+	for i := start; i < len(text); i++ {
+		if text[i] == ':' && (i == len(text)-1 || text[i+1] == ' ') {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// yamlQuotedEnd returns the index of the closing quote of the quoted scalar at the start of text, or -1.
+func yamlQuotedEnd(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a trailing comment from a line, ignoring # characters within quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			// Quotes only start a quoted scalar at the beginning of a token
+			if i == 0 || strings.ContainsRune(" \t[{,:-", rune(line[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlFlowComplete reports whether all brackets and braces in text are closed.
+func yamlFlowComplete(text string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// parseYAMLFlow parses the flow collection or scalar at the start of text and returns the remaining text.
+func parseYAMLFlow(text string) (interface{}, string, error) {
+	text = strings.TrimLeft(text, " ")
+	if text == "" {
+		return nil, "", fmt.Errorf("unexpected end of flow collection")
+	}
+
+	switch text[0] {
+	case '[':
+		items := []interface{}{}
+		rest := strings.TrimLeft(text[1:], " ")
+		for {
+			if strings.HasPrefix(rest, "]") {
+				return items, rest[1:], nil
+			}
+			item, remaining, err := parseYAMLFlow(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			items = append(items, item)
+			rest = strings.TrimLeft(remaining, " ")
+			switch {
+			case strings.HasPrefix(rest, ","):
+				rest = strings.TrimLeft(rest[1:], " ")
+			case !strings.HasPrefix(rest, "]"):
+				return nil, "", fmt.Errorf("expected , or ] in flow sequence, got %q", rest)
+			}
+		}
+	case '{':
+		mapping := map[string]interface{}{}
+		rest := strings.TrimLeft(text[1:], " ")
+		for {
+			if strings.HasPrefix(rest, "}") {
+				return mapping, rest[1:], nil
+			}
+			keyValue, remaining, err := parseYAMLFlow(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			key, ok := keyValue.(string)
+			if !ok {
+				key = fmt.Sprint(keyValue)
+			}
+			rest = strings.TrimLeft(remaining, " ")
+			if !strings.HasPrefix(rest, ":") {
+				return nil, "", fmt.Errorf("expected : after key %q in flow mapping", key)
+			}
+			value, remaining, err := parseYAMLFlow(rest[1:])
+			if err != nil {
+				return nil, "", err
+			}
+			mapping[key] = value
+			rest = strings.TrimLeft(remaining, " ")
+			switch {
+			case strings.HasPrefix(rest, ","):
+				rest = strings.TrimLeft(rest[1:], " ")
+			case !strings.HasPrefix(rest, "}"):
+				return nil, "", fmt.Errorf("expected , or } in flow mapping, got %q", rest)
+			}
+		}
+	case '"', '\'':
+		end := yamlQuotedEnd(text)
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated quoted string %s", text)
+		}
+		value, err := parseYAMLScalarString(text[:end+1])
+		return value, text[end+1:], err
+	case '&', '*':
+		return nil, "", fmt.Errorf("anchors and aliases are not supported")
+	case '!':
+		return nil, "", fmt.Errorf("tags are not supported")
+	case '@', '`':
+		return nil, "", fmt.Errorf("plain scalars cannot start with %q", text[:1])
+	}
+
+	// Plain scalars end at a flow indicator, or at a colon followed by a space
+	end := len(text)
+	for i := 0; i < len(text); i++ {
+		if strings.ContainsRune(",]}", rune(text[i])) || (text[i] == ':' && (i == len(text)-1 || strings.ContainsRune(" ,]}", rune(text[i+1])))) {
+			end = i
+			break
+		}
+	}
+	return resolveYAMLPlainScalar(strings.TrimSpace(text[:end])), text[end:], nil
+}
+
+// parseYAMLScalarString returns the string value of a quoted or plain scalar.
+func parseYAMLScalarString(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	switch text[0] {
+	case '"':
+		if yamlQuotedEnd(text) != len(text)-1 {
+			return "", fmt.Errorf("invalid double quoted string %s", text)
+		}
+		// YAML escapes are a superset of Go's, apart from the escaped slash
+		value, err := strconv.Unquote(strings.ReplaceAll(text, `\/`, "/"))
+		if err != nil {
+			return "", fmt.Errorf("invalid double quoted string %s", text)
+		}
+		return value, nil
+	case '\'':
+		if yamlQuotedEnd(text) != len(text)-1 {
+			return "", fmt.Errorf("invalid single quoted string %s", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+
+	return text, nil
+}
+
+// resolveYAMLPlainScalar resolves a plain scalar to null, a boolean, a number or a string,
+// following the YAML 1.2 core schema.
+func resolveYAMLPlainScalar(text string) interface{} {
+	switch text {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1)
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1)
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN()
+	}
+
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return i
+	}
+	if strings.HasPrefix(text, "0x") {
+		if i, err := strconv.ParseInt(text[2:], 16, 64); err == nil {
+			return i
+		}
+	}
+	if strings.HasPrefix(text, "0o") {
+		if i, err := strconv.ParseInt(text[2:], 8, 64); err == nil {
+			return i
+		}
+	}
+	if strings.ContainsAny(text, "0123456789") && !strings.ContainsAny(text, "_xXpP") {
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+
+	return text
+}
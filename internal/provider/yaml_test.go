@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  interface{}
+	}{
+		{
+			name:  "empty document",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "comments only",
+			input: "# routes\n\n  # none yet\n",
+			want:  nil,
+		},
+		{
+			name:  "document markers",
+			input: "---\nname: app\n...\n",
+			want:  map[string]interface{}{"name": "app"},
+		},
+		{
+			name:  "block mapping",
+			input: "name: app\nfrom: https://app.example.com\nto: http://app.internal:8080\n",
+			want: map[string]interface{}{
+				"name": "app",
+				"from": "https://app.example.com",
+				"to":   "http://app.internal:8080",
+			},
+		},
+		{
+			name:  "nested mappings",
+			input: "route:\n  name: app\n  timeouts:\n    read: 30s\n",
+			want: map[string]interface{}{
+				"route": map[string]interface{}{
+					"name":     "app",
+					"timeouts": map[string]interface{}{"read": "30s"},
+				},
+			},
+		},
+		{
+			name:  "block sequence",
+			input: "- a\n- b\n-\n  c\n",
+			want:  []interface{}{"a", "b", "c"},
+		},
+		{
+			name:  "sequence of mappings",
+			input: "routes:\n  - from: https://a.example.com\n    to: [http://a]\n  - from: https://b.example.com\n    to:\n      - http://b\n",
+			want: map[string]interface{}{
+				"routes": []interface{}{
+					map[string]interface{}{"from": "https://a.example.com", "to": []interface{}{"http://a"}},
+					map[string]interface{}{"from": "https://b.example.com", "to": []interface{}{"http://b"}},
+				},
+			},
+		},
+		{
+			name:  "sequence indented at the level of its key",
+			input: "to:\n- http://a\n- http://b\nname: app\n",
+			want: map[string]interface{}{
+				"to":   []interface{}{"http://a", "http://b"},
+				"name": "app",
+			},
+		},
+		{
+			name:  "nested sequences",
+			input: "- - a\n  - b\n- - c\n",
+			want:  []interface{}{[]interface{}{"a", "b"}, []interface{}{"c"}},
+		},
+		{
+			name:  "flow collections",
+			input: "to: [http://a, \"http://b\"]\nheaders: {X-Env: prod, X-Team: 'core'}\nempty: []\nnone: {}\n",
+			want: map[string]interface{}{
+				"to":      []interface{}{"http://a", "http://b"},
+				"headers": map[string]interface{}{"X-Env": "prod", "X-Team": "core"},
+				"empty":   []interface{}{},
+				"none":    map[string]interface{}{},
+			},
+		},
+		{
+			name:  "flow collection over multiple lines",
+			input: "to: [\n  http://a,\n  http://b\n]\n",
+			want:  map[string]interface{}{"to": []interface{}{"http://a", "http://b"}},
+		},
+		{
+			name:  "scalar types",
+			input: "int: 42\nnegative: -7\nhex: 0x1f\noctal: 0o17\nfloat: 1.5\nexp: 1e3\nyes: true\nno: False\nnull: ~\nempty:\nversion: 1.2.3\n",
+			want: map[string]interface{}{
+				"int":      int64(42),
+				"negative": int64(-7),
+				"hex":      int64(31),
+				"octal":    int64(15),
+				"float":    1.5,
+				"exp":      1000.0,
+				"yes":      true,
+				"no":       false,
+				"null":     nil,
+				"empty":    nil,
+				"version":  "1.2.3",
+			},
+		},
+		{
+			name:  "large integers survive",
+			input: "big: 9007199254740993\n",
+			want:  map[string]interface{}{"big": int64(9007199254740993)},
+		},
+		{
+			name:  "quoted scalars",
+			input: "double: \"a: b # c\"\nsingle: 'it''s'\nescaped: \"tab\\there\\/there\"\nnumber: \"42\"\n\"quoted key\": value\n",
+			want: map[string]interface{}{
+				"double":     "a: b # c",
+				"single":     "it's",
+				"escaped":    "tab\there/there",
+				"number":     "42",
+				"quoted key": "value",
+			},
+		},
+		{
+			name:  "comments",
+			input: "name: app # the app\nurl: https://example.com/#anchor\n# trailing\n",
+			want: map[string]interface{}{
+				"name": "app",
+				"url":  "https://example.com/#anchor",
+			},
+		},
+		{
+			name:  "folded plain scalar",
+			input: "description: a long\n  description\n  over lines\n",
+			want:  map[string]interface{}{"description": "a long description over lines"},
+		},
+		{
+			name:  "literal block scalar",
+			input: "ppl: |\n  allow:\n    or:\n      - email:\n          is: a@example.com\nnext: x\n",
+			want: map[string]interface{}{
+				"ppl":  "allow:\n  or:\n    - email:\n        is: a@example.com\n",
+				"next": "x",
+			},
+		},
+		{
+			name:  "folded block scalar",
+			input: "text: >\n  one\n  two\n\n  three\n",
+			want:  map[string]interface{}{"text": "one two\nthree\n"},
+		},
+		{
+			name:  "folded block scalar with more indented lines",
+			input: "text: >\n  a\n    code\n  b\n\n    more\n",
+			want:  map[string]interface{}{"text": "a\n  code\nb\n\n  more\n"},
+		},
+		{
+			name:  "block scalar chomping",
+			input: "strip: |-\n  a\n\nkeep: |+\n  b\n\nclip: |\n  c\n\n",
+			want: map[string]interface{}{
+				"strip": "a",
+				"keep":  "b\n\n",
+				"clip":  "c\n",
+			},
+		},
+		{
+			name:  "windows line endings",
+			input: "name: app\r\nto: http://a\r\n",
+			want:  map[string]interface{}{"name": "app", "to": "http://a"},
+		},
+		{
+			name:  "special floats",
+			input: "- .inf\n- -.Inf\n",
+			want:  []interface{}{math.Inf(1), math.Inf(-1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeYAML([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeYAML() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeYAMLRejectsUnsupportedInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "mapping value in plain scalar",
+			input:   "a: b: c\n",
+			wantErr: "mapping values are not allowed",
+		},
+		{
+			name:    "mapping value in sequence item",
+			input:   "- a: b: c\n",
+			wantErr: "mapping values are not allowed",
+		},
+		{
+			name:    "plain scalar ending with a colon",
+			input:   "a: b:\n",
+			wantErr: "mapping values are not allowed",
+		},
+		{
+			name:    "mapping value in flow mapping",
+			input:   "a: {b: c: d}\n",
+			wantErr: "expected , or }",
+		},
+		{
+			name:    "tab indentation",
+			input:   "route:\n\tname: app\n",
+			wantErr: "tabs are not allowed",
+		},
+		{
+			name:    "tab after spaces",
+			input:   "route:\n  \tname: app\n",
+			wantErr: "tabs are not allowed",
+		},
+		{
+			name:    "anchor",
+			input:   "base: &base\n  name: app\n",
+			wantErr: "anchors and aliases are not supported",
+		},
+		{
+			name:    "alias",
+			input:   "route: *base\n",
+			wantErr: "anchors and aliases are not supported",
+		},
+		{
+			name:    "alias in sequence",
+			input:   "- *base\n",
+			wantErr: "anchors and aliases are not supported",
+		},
+		{
+			name:    "alias in flow sequence",
+			input:   "to: [http://a, *b]\n",
+			wantErr: "anchors and aliases are not supported",
+		},
+		{
+			name:    "anchored key",
+			input:   "&key name: app\n",
+			wantErr: "anchors and aliases are not supported",
+		},
+		{
+			name:    "tag",
+			input:   "port: !!str 80\n",
+			wantErr: "tags are not supported",
+		},
+		{
+			name:    "tag in flow sequence",
+			input:   "ports: [!!str 80]\n",
+			wantErr: "tags are not supported",
+		},
+		{
+			name:    "multiple documents",
+			input:   "name: a\n---\nname: b\n",
+			wantErr: "multiple documents are not supported",
+		},
+		{
+			name:    "multiple documents after start marker",
+			input:   "---\n- a\n---\n- b\n",
+			wantErr: "multiple documents are not supported",
+		},
+		{
+			name:    "reserved indicator",
+			input:   "name: @app\n",
+			wantErr: "cannot start with",
+		},
+		{
+			name:    "duplicate key",
+			input:   "name: a\nname: b\n",
+			wantErr: "duplicate key",
+		},
+		{
+			name:    "unexpected indentation",
+			input:   "name: a\n  from: b\n",
+			wantErr: "unexpected indentation",
+		},
+		{
+			name:    "unterminated flow collection",
+			input:   "to: [http://a,\n",
+			wantErr: "unterminated flow collection",
+		},
+		{
+			name:    "unterminated quoted string",
+			input:   "name: \"app\n",
+			wantErr: "invalid double quoted string",
+		},
+		{
+			name:    "content after flow collection",
+			input:   "to: [a] b\n",
+			wantErr: "after flow collection",
+		},
+		{
+			name:    "invalid block scalar header",
+			input:   "ppl: |x\n  a\n",
+			wantErr: "invalid block scalar header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeYAML([]byte(tt.input))
+			if err == nil {
+				t.Fatalf("expected an error containing %q, got %#v", tt.wantErr, got)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got: %s", tt.wantErr, err)
+			}
+		})
+	}
+}
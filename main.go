@@ -31,6 +31,8 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	provider.LogAPIMetrics()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}